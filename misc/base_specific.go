@@ -2,10 +2,12 @@ package misc
 
 import (
 	"fmt"
-	"math"
+
+	"github.com/tigerbot/projecteuler/primes"
 )
 
-// FindCycle finds the repeating part of the division of the two specified integers.
+// FindCycle finds the repeating part of the division of the two specified integers. It does the
+// long division digit by digit, so use CycleLength if only the length of the cycle is needed.
 func FindCycle(num, den int) []int {
 	if num < 1 || den < 1 {
 		panic(fmt.Errorf("must provide position numbers to FindCycle"))
@@ -36,6 +38,31 @@ func FindCycle(num, den int) []int {
 	return decimals[remainders[left]:]
 }
 
+// CycleLength returns the length of the repeating part of the decimal expansion of num/den without
+// having to do the long division. Once the fraction is reduced and all factors of 2 and 5 are
+// removed from the denominator (they only affect the non-repeating part), the length of the cycle
+// is the multiplicative order of 10 modulo what's left. Terminating decimals return 0.
+func CycleLength(num, den int) int {
+	if num < 1 || den < 1 {
+		panic(fmt.Errorf("must provide position numbers to CycleLength"))
+	}
+	den /= primes.GCD(num, den)
+	for den%2 == 0 {
+		den /= 2
+	}
+	for den%5 == 0 {
+		den /= 5
+	}
+	if den == 1 {
+		return 0
+	}
+	order, err := primes.MultiplicativeOrder(10, den)
+	if err != nil {
+		panic(err)
+	}
+	return order
+}
+
 // SplitDigits returns a list of the digits used to represent the number in decimal notation. The
 // first element in the array represents the highest magnitude.
 func SplitDigits(num, base int) []int {
@@ -45,7 +72,10 @@ func SplitDigits(num, base int) []int {
 	if num == 0 {
 		return []int{0}
 	}
-	cnt := int(math.Floor(math.Log10(float64(num))/math.Log10(float64(base))) + 1)
+	cnt := 0
+	for rest := num; rest > 0; rest /= base {
+		cnt++
+	}
 	result := make([]int, cnt)
 	for i := 1; i <= cnt; i++ {
 		result[cnt-i] = int(num % base)
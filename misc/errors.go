@@ -0,0 +1,28 @@
+package misc
+
+import (
+	"fmt"
+
+	"github.com/tigerbot/projecteuler/primes"
+)
+
+// ErrOverflow is returned when a result is too big to fit inside the type it's returned as. It's
+// the same error as primes.ErrOverflow, so either can be used to check errors from both packages.
+var ErrOverflow = primes.ErrOverflow
+
+// ParseError is returned when one of the file readers fails to parse the contents of a file. Line
+// and Column are 1-based, with the column counted in bytes like encoding/csv does.
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -7,21 +7,41 @@ import (
 
 // Factorial calculate n!, panicing if the number is too big to fit inside a 64-bit int.
 func Factorial(num int64) int64 {
+	result, err := TryFactorial(num)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryFactorial calculates n!, returning an error wrapping ErrOverflow if the number is too big to
+// fit inside a 64-bit int.
+func TryFactorial(num int64) (int64, error) {
 	var result big.Int
 	result.MulRange(2, num)
 	if !result.IsInt64() {
-		panic(fmt.Errorf("%d! does not fit inside a 64-bit int", num))
+		return 0, fmt.Errorf("%d! does not fit inside a 64-bit int: %w", num, ErrOverflow)
 	}
-	return result.Int64()
+	return result.Int64(), nil
 }
 
 // Choose calculates the number of possible combinations when choosing "choices" items from a
 // pool of "total" items where order in which they are chosen does not matter
 func Choose(total, choices int64) int64 {
+	result, err := TryChoose(total, choices)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryChoose is like Choose, but returns an error wrapping ErrOverflow if the result is too big
+// to fit inside a 64-bit int.
+func TryChoose(total, choices int64) (int64, error) {
 	var result big.Int
 	result.Binomial(total, choices)
 	if !result.IsInt64() {
-		panic(fmt.Errorf("(%d %d) does not fit inside a 64-bit int", total, choices))
+		return 0, fmt.Errorf("(%d %d) does not fit inside a 64-bit int: %w", total, choices, ErrOverflow)
 	}
-	return result.Int64()
+	return result.Int64(), nil
 }
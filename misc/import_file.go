@@ -2,82 +2,117 @@ package misc
 
 import (
 	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"strconv"
 )
 
-// ReadNumberGrid reads base10 numbers from a file
-func ReadNumberGrid(name string, sep rune) [][]int {
+// readRecords reads every record from the csv file, calling handle with each one as it's read.
+func readRecords(name string, sep rune, handle func(*csv.Reader, []string) error) error {
 	file, err := os.Open(name)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	defer file.Close()
 
 	reader := csv.NewReader(file)
 	reader.Comma = sep
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var csvErr *csv.ParseError
+			if errors.As(err, &csvErr) {
+				return &ParseError{File: name, Line: csvErr.Line, Column: csvErr.Column, Err: csvErr.Err}
+			}
+			return err
+		}
+		if err := handle(reader, record); err != nil {
+			return err
+		}
+	}
+}
 
-	raw, err := reader.ReadAll()
+// ReadNumberGrid reads base10 numbers from a file, panicking if anything goes wrong.
+func ReadNumberGrid(name string, sep rune) [][]int {
+	result, err := TryReadNumberGrid(name, sep)
 	if err != nil {
 		panic(err)
 	}
+	return result
+}
 
-	result := make([][]int, len(raw))
-	for i, line := range raw {
+// TryReadNumberGrid reads base10 numbers from a file. Any number that can't be parsed results in a
+// *ParseError, which wraps ErrOverflow if the number is too big for an int.
+func TryReadNumberGrid(name string, sep rune) ([][]int, error) {
+	var result [][]int
+	err := readRecords(name, sep, func(reader *csv.Reader, line []string) error {
 		parsedLine := make([]int, len(line))
 		for j, rawNum := range line {
-			if num, err := strconv.Atoi(rawNum); err != nil {
-				panic(err)
-			} else {
-				parsedLine[j] = num
+			num, err := strconv.Atoi(rawNum)
+			if err != nil {
+				row, col := reader.FieldPos(j)
+				parseErr := &ParseError{File: name, Line: row, Column: col, Err: err}
+				if errors.Is(err, strconv.ErrRange) {
+					parseErr.Err = fmt.Errorf("parsing %q: %w", rawNum, ErrOverflow)
+				}
+				return parseErr
 			}
+			parsedLine[j] = num
 		}
-		result[i] = parsedLine
+		result = append(result, parsedLine)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return result
+	return result, nil
 }
 
-// ReadTextGrid reads a grid of arbitrary strings from a file
+// ReadTextGrid reads a grid of arbitrary strings from a file, panicking if anything goes wrong.
 func ReadTextGrid(name string, sep rune) [][]string {
-	file, err := os.Open(name)
+	result, err := TryReadTextGrid(name, sep)
 	if err != nil {
 		panic(err)
 	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.Comma = sep
+	return result
+}
 
-	raw, err := reader.ReadAll()
+// TryReadTextGrid reads a grid of arbitrary strings from a file.
+func TryReadTextGrid(name string, sep rune) ([][]string, error) {
+	var result [][]string
+	err := readRecords(name, sep, func(_ *csv.Reader, line []string) error {
+		result = append(result, line)
+		return nil
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return raw
+	return result, nil
 }
 
-// ReadFlattenText reads a csv and returns the flattened results
+// ReadFlattenText reads a csv and returns the flattened results, panicking if anything goes wrong.
 func ReadFlattenText(name string) []string {
-	file, err := os.Open(name)
+	result, err := TryReadFlattenText(name)
 	if err != nil {
 		panic(err)
 	}
-	defer file.Close()
+	return result
+}
 
-	all, err := csv.NewReader(file).ReadAll()
-	if err != nil {
-		panic(err)
-	}
-	if len(all) == 1 {
-		return all[0]
-	}
-	size := 0
-	for _, line := range all {
-		size += len(line)
-	}
-	result := make([]string, 0, size)
-	for _, line := range all {
+// TryReadFlattenText reads a csv and returns the flattened results.
+func TryReadFlattenText(name string) ([]string, error) {
+	var result []string
+	err := readRecords(name, ',', func(_ *csv.Reader, line []string) error {
 		result = append(result, line...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return result
+	return result, nil
 }
@@ -0,0 +1,94 @@
+package misc
+
+import (
+	"encoding/csv"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func writeTemp(t *testing.T, contents string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(name, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestTryReadNumberGrid(t *testing.T) {
+	name := writeTemp(t, "08 02 22\n49 49 99\n")
+	if r, err := TryReadNumberGrid(name, ' '); err != nil || !reflect.DeepEqual(r, [][]int{{8, 2, 22}, {49, 49, 99}}) {
+		t.Errorf("TryReadNumberGrid returned %d, %v, expected [[8 2 22] [49 49 99]]", r, err)
+	}
+
+	type s struct {
+		contents     string
+		line, column int
+		err          error
+	}
+	expected := []s{
+		{"1,2,3\n4,abc,6\n", 2, 3, strconv.ErrSyntax},
+		{"1,2\n3,4\n5,99999999999999999999\n", 3, 3, ErrOverflow},
+		{"12,-7,\n", 1, 7, strconv.ErrSyntax},
+		{"1,2\n3,4\"5\n", 2, 4, csv.ErrBareQuote},
+		{"1,2\n3\n", 2, 1, csv.ErrFieldCount},
+	}
+
+	for _, e := range expected {
+		name := writeTemp(t, e.contents)
+		_, err := TryReadNumberGrid(name, ',')
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("TryReadNumberGrid(%q) returned error %v, expected a *ParseError", e.contents, err)
+			continue
+		}
+		if parseErr.File != name || parseErr.Line != e.line || parseErr.Column != e.column {
+			t.Errorf("TryReadNumberGrid(%q) returned error at %s:%d:%d, expected %s:%d:%d",
+				e.contents, parseErr.File, parseErr.Line, parseErr.Column, name, e.line, e.column)
+		}
+		if !errors.Is(err, e.err) {
+			t.Errorf("TryReadNumberGrid(%q) returned error %v, expected it to wrap %v", e.contents, err, e.err)
+		}
+	}
+}
+
+func TestTryReadText(t *testing.T) {
+	name := writeTemp(t, "\"MARY\",\"PATRICIA\"\n\"LINDA\",\"BARBARA\"\n")
+	if r, err := TryReadTextGrid(name, ','); err != nil || !reflect.DeepEqual(r, [][]string{{"MARY", "PATRICIA"}, {"LINDA", "BARBARA"}}) {
+		t.Errorf("TryReadTextGrid returned %q, %v, expected [[MARY PATRICIA] [LINDA BARBARA]]", r, err)
+	}
+	if r, err := TryReadFlattenText(name); err != nil || !reflect.DeepEqual(r, []string{"MARY", "PATRICIA", "LINDA", "BARBARA"}) {
+		t.Errorf("TryReadFlattenText returned %q, %v, expected [MARY PATRICIA LINDA BARBARA]", r, err)
+	}
+
+	name = writeTemp(t, "\"MARY\",\"PAT\"RICIA\"\n")
+	var parseErr *ParseError
+	if _, err := TryReadFlattenText(name); !errors.As(err, &parseErr) || !errors.Is(err, csv.ErrQuote) {
+		t.Errorf("TryReadFlattenText returned error %v, expected a *ParseError wrapping csv.ErrQuote", err)
+	} else if parseErr.Line != 1 || parseErr.Column != 12 {
+		t.Errorf("TryReadFlattenText returned error at %d:%d, expected 1:12", parseErr.Line, parseErr.Column)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	if _, err := TryReadTextGrid(missing, ','); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("TryReadTextGrid returned error %v, expected fs.ErrNotExist", err)
+	}
+	if _, err := TryReadNumberGrid(missing, ','); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("TryReadNumberGrid returned error %v, expected fs.ErrNotExist", err)
+	}
+}
+
+func TestReadPanics(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrOverflow) {
+			t.Errorf("ReadNumberGrid panicked with %v, expected an error wrapping ErrOverflow", err)
+		}
+	}()
+	ReadNumberGrid(writeTemp(t, "99999999999999999999\n"), ',')
+}
@@ -0,0 +1,256 @@
+package misc
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/tigerbot/projecteuler/primes"
+)
+
+// checkBase panics if numbers can't be written using the base.
+func checkBase(base int) {
+	if base < 2 {
+		panic(fmt.Errorf("cannot write numbers in base %d", base))
+	}
+}
+
+// appendDigit returns the number with the digit added to the end, and false if the result would
+// overflow an int.
+func appendDigit(num, digit, base int) (int, bool) {
+	if num > (math.MaxInt-digit)/base {
+		return 0, false
+	}
+	return num*base + digit, true
+}
+
+// unitDigits returns every digit that is coprime to the base. Any prime with more than one digit
+// other than the base itself has to end in one of them, otherwise it would share a factor with the
+// base.
+func unitDigits(base int) []int {
+	var result []int
+	for dig := 1; dig < base; dig++ {
+		if primes.GCD(dig, base) == 1 {
+			result = append(result, dig)
+		}
+	}
+	return result
+}
+
+// CircularPrimes returns every prime no bigger than limit where every rotation of its digits in
+// the given base is also prime, in ascending order. Every digit of a circular prime with more than
+// one digit ends up as the last digit of some rotation, so the candidates are built only from the
+// digits that are coprime to the base. It panics with an error wrapping ErrOverflow if the
+// rotations of numbers as long as limit might not fit in an int.
+func CircularPrimes(limit, base int) []int {
+	checkBase(base)
+	length, power := 1, 1
+	for ; power <= limit/base; length++ {
+		power *= base
+	}
+	if power > math.MaxInt/base {
+		panic(fmt.Errorf("rotations of %d-digit numbers in base %d: %w", length, base, ErrOverflow))
+	}
+
+	result := primes.Between(2, min(limit, base-1))
+	digits := unitDigits(base)
+	var build func(num, power int)
+	build = func(num, power int) {
+		for _, dig := range digits {
+			next := num*base + dig
+			if next > limit {
+				return
+			}
+			if isCircular(next, power, base) {
+				result = append(result, next)
+			}
+			if power <= limit/base {
+				build(next, power*base)
+			}
+		}
+	}
+	for _, dig := range digits {
+		build(dig, base)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// isCircular checks every rotation of the number, where power is the value of its leading digit
+// position.
+func isCircular(num, power, base int) bool {
+	for rot := num; ; {
+		if !primes.IsPrime(rot) {
+			return false
+		}
+		if rot = rot%base*power + rot/base; rot == num {
+			return true
+		}
+	}
+}
+
+// RightTruncatablePrimes returns every prime no bigger than limit that stays prime as digits are
+// removed from the right in the given base, one at a time, in ascending order. The single digit
+// primes are included. Candidates are built by appending digits to the shorter ones, so only
+// numbers whose prefixes are all prime are ever tested.
+func RightTruncatablePrimes(limit, base int) []int {
+	checkBase(base)
+	result := primes.Between(2, min(limit, base-1))
+	digits := unitDigits(base)
+	for level := result; len(level) > 0; {
+		var next []int
+		for _, prime := range level {
+			for _, dig := range digits {
+				if num, ok := appendDigit(prime, dig, base); ok && num <= limit && primes.IsPrime(num) {
+					next = append(next, num)
+				}
+			}
+		}
+		result = append(result, next...)
+		level = next
+	}
+	slices.Sort(result)
+	return result
+}
+
+// LeftTruncatablePrimes returns every prime no bigger than limit that stays prime as digits are
+// removed from the left in the given base, one at a time, in ascending order. The single digit
+// primes are included, and none of the primes can contain a 0 since removing the digits before it
+// would leave a leading zero. Candidates are built by prepending digits to the shorter ones, so
+// only numbers whose suffixes are all prime are ever tested.
+func LeftTruncatablePrimes(limit, base int) []int {
+	checkBase(base)
+	result := primes.Between(2, min(limit, base-1))
+	level := result
+	for power := base; len(level) > 0 && power <= limit; {
+		var next []int
+		for dig := 1; dig < base && dig <= limit/power; dig++ {
+			for _, prime := range level {
+				if num := dig * power; prime <= limit-num && primes.IsPrime(num+prime) {
+					next = append(next, num+prime)
+				}
+			}
+		}
+		result = append(result, next...)
+		level = next
+		if power > math.MaxInt/base {
+			break
+		}
+		power *= base
+	}
+	slices.Sort(result)
+	return result
+}
+
+// PalindromicPrimes returns every prime no bigger than limit that reads the same in both
+// directions in the given base, in ascending order. The palindromes are built from the digits of
+// their first half, which have to start with a digit coprime to the base since it's also the last
+// digit. Palindromes with an even number of digits are all divisible by base+1, so the only one
+// that can be prime is base+1 itself.
+func PalindromicPrimes(limit, base int) []int {
+	checkBase(base)
+	result := primes.Between(2, min(limit, base-1))
+	if base+1 <= limit && primes.IsPrime(base+1) {
+		result = append(result, base+1)
+	}
+
+	// Each palindrome with an odd number of digits is made from the number formed by its first
+	// half and middle digit, which is mirrored without repeating the middle digit. lo is the
+	// smallest of these numbers for the current length.
+	for lo := base; lo <= limit/lo; lo *= base {
+		for first := lo; first < lo*base; first++ {
+			if primes.GCD(first/lo, base) != 1 {
+				// Skip straight to the next leading digit.
+				first += lo - 1
+				continue
+			}
+			num, ok := first, true
+			for rest := first / base; rest > 0 && ok; rest /= base {
+				num, ok = appendDigit(num, rest%base, base)
+			}
+			if !ok || num > limit {
+				return result
+			}
+			if primes.IsPrime(num) {
+				result = append(result, num)
+			}
+		}
+	}
+	return result
+}
+
+// ReplacementFamilies finds the families of primes with the given number of digits in the given
+// base that are made by replacing the same set of positions with the same digit, and that have
+// at least size members. Each family is a list of its primes in ascending order, and the families
+// are ordered by their smallest prime. The family templates are built digit by digit, where a
+// last digit that isn't replaced has to be coprime to the base, and each template is abandoned as
+// soon as too many of its replacements turn out to be composite.
+func ReplacementFamilies(length, size, base int) [][]int {
+	checkBase(base)
+	for i, largest := 0, 0; i < length; i++ {
+		var ok bool
+		if largest, ok = appendDigit(largest, base-1, base); !ok {
+			panic(fmt.Errorf("%d-digit numbers in base %d: %w", length, base, ErrOverflow))
+		}
+	}
+
+	var result [][]int
+	all, units := make([]int, base), unitDigits(base)
+	for dig := range all {
+		all[dig] = dig
+	}
+	if length == 2 && primes.IsPrime(base) {
+		// The base itself is written as 10.
+		units = append([]int{0}, units...)
+	}
+	// fixed is the value of the template with every replaced position set to 0, and mask is the
+	// value with every replaced position set to 1 and everything else set to 0.
+	var build func(pos, fixed, mask int, leading bool)
+	build = func(pos, fixed, mask int, leading bool) {
+		if pos == length {
+			if mask != 0 {
+				if family := replaceFamily(fixed, mask, size, base, leading); family != nil {
+					result = append(result, family)
+				}
+			}
+			return
+		}
+		digits := all
+		if pos == 0 {
+			digits = all[1:]
+		} else if pos == length-1 {
+			digits = units
+		}
+		for _, dig := range digits {
+			build(pos+1, fixed*base+dig, mask*base, leading)
+		}
+		build(pos+1, fixed*base, mask*base+1, leading || pos == 0)
+	}
+	build(0, 0, 0, false)
+
+	slices.SortFunc(result, func(a, b []int) int { return cmp.Compare(a[0], b[0]) })
+	return result
+}
+
+// replaceFamily returns the primes made by filling the template with each digit, or nil as soon
+// as it's clear there won't be at least size of them. The replacement can't be 0 if it includes
+// the leading digit.
+func replaceFamily(fixed, mask, size, base int, leading bool) []int {
+	first := 0
+	if leading {
+		first = 1
+	}
+	var family []int
+	for dig := first; dig < base; dig++ {
+		if num := fixed + dig*mask; primes.IsPrime(num) {
+			family = append(family, num)
+		} else if len(family)+base-1-dig < size {
+			return nil
+		}
+	}
+	if len(family) < size {
+		return nil
+	}
+	return family
+}
@@ -0,0 +1,159 @@
+package misc
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/tigerbot/projecteuler/primes"
+)
+
+func TestPrimeFamiliesBruteForce(t *testing.T) {
+	const limit = 100000
+	for _, base := range []int{2, 3, 10, 16} {
+		var circular, left, right, palindromic []int
+		for num := 2; num <= limit; num++ {
+			digits := SplitDigits(num, base)
+
+			isCircular := true
+			for i := range digits {
+				rotated := append(slices.Clone(digits[i:]), digits[:i]...)
+				isCircular = isCircular && primes.IsPrime(MergeDigits(rotated, base))
+			}
+			if isCircular {
+				circular = append(circular, num)
+			}
+
+			isLeft, isRight := true, true
+			for i := range digits {
+				isLeft = isLeft && digits[i] != 0 && primes.IsPrime(MergeDigits(digits[i:], base))
+				isRight = isRight && primes.IsPrime(MergeDigits(digits[:i+1], base))
+			}
+			if isLeft {
+				left = append(left, num)
+			}
+			if isRight {
+				right = append(right, num)
+			}
+
+			reversed := slices.Clone(digits)
+			slices.Reverse(reversed)
+			if slices.Equal(digits, reversed) && primes.IsPrime(num) {
+				palindromic = append(palindromic, num)
+			}
+		}
+
+		if r := CircularPrimes(limit, base); !reflect.DeepEqual(r, circular) {
+			t.Errorf("CircularPrimes(%d, %d) returned %d primes, expected %d", limit, base, len(r), len(circular))
+		}
+		if r := LeftTruncatablePrimes(limit, base); !reflect.DeepEqual(r, left) {
+			t.Errorf("LeftTruncatablePrimes(%d, %d) returned %d primes, expected %d", limit, base, len(r), len(left))
+		}
+		if r := RightTruncatablePrimes(limit, base); !reflect.DeepEqual(r, right) {
+			t.Errorf("RightTruncatablePrimes(%d, %d) returned %d primes, expected %d", limit, base, len(r), len(right))
+		}
+		if r := PalindromicPrimes(limit, base); !reflect.DeepEqual(r, palindromic) {
+			t.Errorf("PalindromicPrimes(%d, %d) returned %d primes, expected %d", limit, base, len(r), len(palindromic))
+		}
+	}
+}
+
+func TestReplacementFamiliesBruteForce(t *testing.T) {
+	for _, base := range []int{2, 3, 10, 16} {
+		for length := 1; length <= 4; length++ {
+			size := min(base-1, 3)
+			// Every template is a choice of fixed digit or replacement for each position.
+			var expected [][]int
+			templates := 1
+			for i := 0; i < length; i++ {
+				templates *= base + 1
+			}
+			for ind := 0; ind < templates; ind++ {
+				pattern := make([]int, length)
+				replaced := false
+				for i, rest := length-1, ind; i >= 0; i, rest = i-1, rest/(base+1) {
+					pattern[i] = rest % (base + 1)
+					replaced = replaced || pattern[i] == base
+				}
+				if !replaced || pattern[0] == 0 {
+					continue
+				}
+				var family []int
+				for dig := 0; dig < base; dig++ {
+					digits := make([]int, length)
+					for i, val := range pattern {
+						if digits[i] = val; val == base {
+							digits[i] = dig
+						}
+					}
+					if digits[0] != 0 && primes.IsPrime(MergeDigits(digits, base)) {
+						family = append(family, MergeDigits(digits, base))
+					}
+				}
+				if len(family) >= size {
+					expected = append(expected, family)
+				}
+			}
+			slices.SortFunc(expected, func(a, b []int) int { return a[0] - b[0] })
+
+			if r := ReplacementFamilies(length, size, base); !reflect.DeepEqual(r, expected) {
+				t.Errorf("ReplacementFamilies(%d, %d, %d) returned %d, expected %d", length, size, base, r, expected)
+			}
+		}
+	}
+}
+
+func TestPrimeFamiliesKnown(t *testing.T) {
+	if r := CircularPrimes(1e6, 10); len(r) != 55 {
+		t.Errorf("CircularPrimes(10^6, 10) returned %d primes, expected 55", len(r))
+	}
+	if r := CircularPrimes(100, 10); !reflect.DeepEqual(r, []int{2, 3, 5, 7, 11, 13, 17, 31, 37, 71, 73, 79, 97}) {
+		t.Errorf("CircularPrimes(100, 10) returned %d, expected [2,3,5,7,11,13,17,31,37,71,73,79,97]", r)
+	}
+
+	right := RightTruncatablePrimes(math.MaxInt, 10)
+	if len(right) != 83 || right[len(right)-1] != 73939133 {
+		t.Errorf("RightTruncatablePrimes(MaxInt, 10) returned %d primes ending in %d, expected 83 ending in 73939133",
+			len(right), right[len(right)-1])
+	}
+	var both []int
+	left := LeftTruncatablePrimes(1e18, 10)
+	for _, prime := range right {
+		if _, found := slices.BinarySearch(left, prime); found && prime > 10 {
+			both = append(both, prime)
+		}
+	}
+	if expected := []int{23, 37, 53, 73, 313, 317, 373, 797, 3137, 3797, 739397}; !reflect.DeepEqual(both, expected) {
+		t.Errorf("expected the two-sided truncatable primes to be %d; got %d", expected, both)
+	}
+
+	if r := PalindromicPrimes(1e9, 10); len(r) != 5953 {
+		t.Errorf("PalindromicPrimes(10^9, 10) returned %d primes, expected 5953", len(r))
+	}
+
+	families := ReplacementFamilies(6, 8, 10)
+	if expected := [][]int{{121313, 222323, 323333, 424343, 525353, 626363, 828383, 929393}}; !reflect.DeepEqual(families, expected) {
+		t.Errorf("ReplacementFamilies(6, 8, 10) returned %d, expected %d", families, expected)
+	}
+	if r := ReplacementFamilies(2, 6, 10); !reflect.DeepEqual(r, [][]int{{13, 23, 43, 53, 73, 83}}) {
+		t.Errorf("ReplacementFamilies(2, 6, 10) returned %d, expected [[13 23 43 53 73 83]]", r)
+	}
+}
+
+func TestPrimeFamiliesOverflow(t *testing.T) {
+	expectPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, ErrOverflow) {
+				t.Errorf("%s panicked with %v, expected an error wrapping ErrOverflow", name, err)
+			}
+		}()
+		fn()
+	}
+	expectPanic("CircularPrimes(MaxInt, 10)", func() { CircularPrimes(math.MaxInt, 10) })
+	expectPanic("CircularPrimes(2^62, 2)", func() { CircularPrimes(1<<62, 2) })
+	expectPanic("ReplacementFamilies(20, 2, 10)", func() { ReplacementFamilies(20, 2, 10) })
+	expectPanic("ReplacementFamilies(16, 2, 16)", func() { ReplacementFamilies(16, 2, 16) })
+}
@@ -2,7 +2,6 @@ package misc
 
 import (
 	"fmt"
-	"math"
 	"strings"
 )
 
@@ -94,9 +93,15 @@ func NameNumber(num int) string {
 		num *= -1
 	}
 
+	// Find the largest group of 3 digits, working with the powers directly since the float based
+	// logarithm can be off for numbers that aren't exactly representable.
+	mag, pow := 0, 1
+	for num/pow >= 1000 {
+		mag, pow = mag+3, pow*1000
+	}
+
 	first := true
-	for mag := 3 * int(math.Floor(math.Log10(float64(num))/3)); mag >= 0; mag -= 3 {
-		pow := int(math.Pow10(int(mag)))
+	for ; mag >= 0; mag, pow = mag-3, pow/1000 {
 		if grpName := nameGroup(num / pow); grpName != "" {
 			if !first {
 				result += ", "
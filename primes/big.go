@@ -0,0 +1,201 @@
+package primes
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// BigFactor is a single prime and the number of times it appears in a factorization.
+type BigFactor struct {
+	Prime *big.Int
+	Exp   int
+}
+
+var bigOne = big.NewInt(1)
+
+// IsProbablePrimeBig checks if the number is prime. Numbers that fit in 64 bits are checked
+// exactly, while larger numbers go through trial division by the small primes followed by the
+// Baillie-PSW test (which is what big.Int.ProbablyPrime(0) implements). No composite number is
+// known to pass the Baillie-PSW test.
+func IsProbablePrimeBig(num *big.Int) bool {
+	if num.Sign() <= 0 {
+		return false
+	}
+	if num.IsUint64() {
+		return isPrime64(num.Uint64())
+	}
+
+	var prime, rem big.Int
+	for _, p := range defaultSieve.expand(1000) {
+		if p > 1000 {
+			break
+		}
+		if rem.Mod(num, prime.SetInt64(int64(p))).Sign() == 0 {
+			return false
+		}
+	}
+	return num.ProbablyPrime(0)
+}
+
+// FactorBig is like TryFactorBig, but panics if the number isn't a natural number.
+func FactorBig(num *big.Int) []BigFactor {
+	result, err := TryFactorBig(num)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryFactorBig returns the prime factorization of the given number as a list of primes and their
+// exponents in ascending order. Unlike FactorMap the result isn't a map, since a *big.Int key would
+// be compared by pointer rather than by value. It uses the sieve for trial division by the small
+// primes, and then Pollard's rho algorithm for anything left. Numbers with more than one large
+// prime factor (over 20 digits or so) can take an impractical amount of time to factor. It returns
+// an error wrapping ErrNotNatural if the number isn't a natural number.
+func TryFactorBig(num *big.Int) ([]BigFactor, error) {
+	if num.Sign() <= 0 {
+		return nil, fmt.Errorf("cannot factor %s: %w", num, ErrNotNatural)
+	}
+
+	counts := map[string]*BigFactor{}
+	add := func(prime *big.Int) {
+		key := prime.String()
+		if f := counts[key]; f != nil {
+			f.Exp++
+		} else {
+			counts[key] = &BigFactor{Prime: new(big.Int).Set(prime), Exp: 1}
+		}
+	}
+
+	// Anything small enough to fit in an int can just use the normal factoring.
+	if num.IsInt64() {
+		for _, prime := range defaultSieve.Factor(int(num.Int64())) {
+			if prime != 1 {
+				add(big.NewInt(int64(prime)))
+			}
+		}
+		return sortBigFactors(counts), nil
+	}
+
+	rest := new(big.Int).Set(num)
+	var prime, quo, rem big.Int
+	for _, p := range defaultSieve.expand(trialLimit) {
+		if p > trialLimit {
+			break
+		}
+		prime.SetInt64(int64(p))
+		for {
+			if quo.QuoRem(rest, &prime, &rem); rem.Sign() != 0 {
+				break
+			}
+			rest.Set(&quo)
+			add(&prime)
+		}
+	}
+
+	// Whatever is left has no factors below the trial limit, so split it with Pollard's rho.
+	var split func(*big.Int)
+	split = func(val *big.Int) {
+		switch {
+		case val.Cmp(bigOne) == 0:
+		case val.IsUint64():
+			for _, prime := range appendRhoFactors(nil, val.Uint64()) {
+				add(new(big.Int).SetUint64(prime))
+			}
+		case IsProbablePrimeBig(val):
+			add(val)
+		default:
+			div := brentBig(val)
+			split(div)
+			split(new(big.Int).Quo(val, div))
+		}
+	}
+	split(rest)
+	return sortBigFactors(counts), nil
+}
+
+func sortBigFactors(counts map[string]*BigFactor) []BigFactor {
+	result := make([]BigFactor, 0, len(counts))
+	for _, f := range counts {
+		result = append(result, *f)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Prime.Cmp(result[j].Prime) < 0 })
+	return result
+}
+
+// brentBig is the big.Int version of brent. It keeps trying different polynomials until it finds
+// a non-trivial factor, so it must only be called with composite numbers.
+func brentBig(num *big.Int) *big.Int {
+	const batch = 128
+	var tmp big.Int
+	c := new(big.Int)
+	step := func(x *big.Int) {
+		x.Mul(x, x)
+		x.Add(x, c)
+		x.Mod(x, num)
+	}
+	absDiff := func(a, b *big.Int) *big.Int {
+		return tmp.Abs(tmp.Sub(a, b))
+	}
+
+	for c.SetInt64(1); ; c.Add(c, bigOne) {
+		x, y, ys := new(big.Int), big.NewInt(2), new(big.Int)
+		q, g := big.NewInt(1), big.NewInt(1)
+		for r := 1; g.Cmp(bigOne) == 0; r *= 2 {
+			x.Set(y)
+			for i := 0; i < r; i++ {
+				step(y)
+			}
+			for k := 0; k < r && g.Cmp(bigOne) == 0; k += batch {
+				ys.Set(y)
+				for i := 0; i < batch && i < r-k; i++ {
+					step(y)
+					q.Mul(q, absDiff(x, y))
+					q.Mod(q, num)
+				}
+				g.GCD(nil, nil, q, num)
+			}
+		}
+		if g.Cmp(num) == 0 {
+			for g.SetInt64(1); g.Cmp(bigOne) == 0; {
+				step(ys)
+				g.GCD(nil, nil, absDiff(x, ys), num)
+			}
+		}
+		if g.Cmp(num) != 0 {
+			return g
+		}
+	}
+}
+
+// DivisorsBig finds all numbers that divide evenly into the provided number in ascending order.
+func DivisorsBig(num *big.Int) []*big.Int {
+	result := []*big.Int{big.NewInt(1)}
+	for _, f := range FactorBig(num) {
+		prev := result
+		factor := new(big.Int).Set(f.Prime)
+		for i := 1; i <= f.Exp; i++ {
+			for _, div := range prev {
+				result = append(result, new(big.Int).Mul(div, factor))
+			}
+			factor = new(big.Int).Mul(factor, f.Prime)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Cmp(result[j]) < 0 })
+	return result
+}
+
+// EulerPhiBig calculates the number of positive integers less than n that are relatively prime
+// to n.
+func EulerPhiBig(num *big.Int) *big.Int {
+	result := big.NewInt(1)
+	var term big.Int
+	for _, f := range FactorBig(num) {
+		term.Sub(f.Prime, bigOne)
+		result.Mul(result, &term)
+		term.Exp(f.Prime, big.NewInt(int64(f.Exp-1)), nil)
+		result.Mul(result, &term)
+	}
+	return result
+}
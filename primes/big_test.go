@@ -0,0 +1,76 @@
+package primes
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func parseBig(t *testing.T, str string) *big.Int {
+	num, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		t.Fatalf("failed to parse %q as a big.Int", str)
+	}
+	return num
+}
+
+func TestIsProbablePrimeBig(t *testing.T) {
+	type s struct {
+		num   string
+		prime bool
+	}
+	expected := []s{
+		{"1", false},
+		{"7919", true},
+		{"18446744073709551557", true},
+		{"18446744073709551617", false},
+		{"11111111111111111111111", true},
+		{"170141183460469231731687303715884105727", true},
+		{"340282366920938463463374607431768211457", false},
+	}
+
+	for _, e := range expected {
+		if r := IsProbablePrimeBig(parseBig(t, e.num)); r != e.prime {
+			t.Errorf("IsProbablePrimeBig(%s) returned %t, expected %t", e.num, r, e.prime)
+		}
+	}
+}
+
+func TestFactorBig(t *testing.T) {
+	type s struct {
+		num     string
+		factors string
+	}
+	expected := []s{
+		{"60", "[2^2 3^1 5^1]"},
+		{"18446744073709551617", "[274177^1 67280421310721^1]"},
+		{"340282366920938463463374607431768211455", "[3^1 5^1 17^1 257^1 641^1 65537^1 274177^1 6700417^1 67280421310721^1]"},
+		{"1267650600228229401496703205376", "[2^100]"},
+		{"1000000000000000000000000000000000000000000", "[2^42 5^42]"},
+	}
+
+	for _, e := range expected {
+		var parts []string
+		for _, f := range FactorBig(parseBig(t, e.num)) {
+			parts = append(parts, fmt.Sprintf("%s^%d", f.Prime, f.Exp))
+		}
+		if r := fmt.Sprint(parts); r != e.factors {
+			t.Errorf("FactorBig(%s) returned %s, expected %s", e.num, r, e.factors)
+		}
+	}
+
+	for _, num := range []string{"0", "-60", "-18446744073709551617"} {
+		if r, err := TryFactorBig(parseBig(t, num)); !errors.Is(err, ErrNotNatural) {
+			t.Errorf("TryFactorBig(%s) returned %v, %v, expected ErrNotNatural", num, r, err)
+		}
+	}
+
+	num := parseBig(t, "18446744073709551617")
+	if r := DivisorsBig(num); len(r) != 4 || r[1].Int64() != 274177 || r[3].Cmp(num) != 0 {
+		t.Errorf("DivisorsBig(%s) returned %s, expected [1 274177 67280421310721 %s]", num, r, num)
+	}
+	if r, e := EulerPhiBig(num), parseBig(t, "18446676793287966720"); r.Cmp(e) != 0 {
+		t.Errorf("EulerPhiBig(%s) returned %s, expected %s", num, r, e)
+	}
+}
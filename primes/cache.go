@@ -0,0 +1,126 @@
+package primes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// The cache file starts with the magic string and the format version, followed by the limit the
+// sieve reached and the number of primes found as uvarints. Then comes each prime as the
+// uvarint difference from the previous one (halved after the first two primes, since the gaps
+// between odd primes are always even), and finally the big-endian CRC-32 of everything before it.
+const (
+	cacheMagic   = "PRMS"
+	cacheVersion = byte(1)
+)
+
+// SaveCache writes all of the primes found by the default sieve to w.
+func SaveCache(w io.Writer) error { return defaultSieve.SaveCache(w) }
+
+// SaveCache writes all of the primes the sieve has found to w in a compact binary format that can
+// be read back with LoadCache.
+func (s *Sieve) SaveCache(w io.Writer) error {
+	list, limit := s.cached()
+
+	sum := crc32.NewIEEE()
+	buf := bufio.NewWriter(io.MultiWriter(w, sum))
+	scratch := make([]byte, binary.MaxVarintLen64)
+	writeUvarint := func(val int) {
+		buf.Write(scratch[:binary.PutUvarint(scratch, uint64(val))])
+	}
+
+	buf.WriteString(cacheMagic)
+	buf.WriteByte(cacheVersion)
+	writeUvarint(limit)
+	writeUvarint(len(list))
+	prev := 0
+	for i, prime := range list {
+		if i < 2 {
+			writeUvarint(prime - prev)
+		} else {
+			writeUvarint((prime - prev) / 2)
+		}
+		prev = prime
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, sum.Sum32())
+}
+
+// LoadCache reads primes saved by SaveCache into the default sieve.
+func LoadCache(r io.Reader) error { return defaultSieve.LoadCache(r) }
+
+// LoadCache reads primes saved by SaveCache. The loaded primes replace the sieve's cache if they
+// cover a larger range than what the sieve has already found, otherwise they are discarded.
+func (s *Sieve) LoadCache(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(data) < len(cacheMagic)+1+4 || !bytes.HasPrefix(data, []byte(cacheMagic)) {
+		return fmt.Errorf("%w: missing header", ErrCorruptCache)
+	}
+	if v := data[len(cacheMagic)]; v != cacheVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrCorruptCache, v)
+	}
+	data, expected := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	if sum := crc32.ChecksumIEEE(data); sum != expected {
+		return fmt.Errorf("%w: checksum mismatch %08x != %08x", ErrCorruptCache, sum, expected)
+	}
+
+	data = data[len(cacheMagic)+1:]
+	readUvarint := func() (int, error) {
+		val, n := binary.Uvarint(data)
+		if n <= 0 || val > uint64(^uint(0)>>1) {
+			return 0, fmt.Errorf("%w: invalid varint", ErrCorruptCache)
+		}
+		data = data[n:]
+		return int(val), nil
+	}
+	limit, err := readUvarint()
+	if err != nil {
+		return err
+	}
+	cnt, err := readUvarint()
+	if err != nil {
+		return err
+	}
+	if cnt > len(data) {
+		return fmt.Errorf("%w: claims %d primes, but only has %d bytes left", ErrCorruptCache, cnt, len(data))
+	}
+
+	list, prev := make([]int, cnt), 0
+	for i := range list {
+		diff, err := readUvarint()
+		if err != nil {
+			return err
+		}
+		if i >= 2 {
+			diff *= 2
+		}
+		if diff <= 0 {
+			return fmt.Errorf("%w: not in ascending order at index %d", ErrCorruptCache, i)
+		}
+		list[i] = prev + diff
+		prev = list[i]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d unexpected trailing bytes", ErrCorruptCache, len(data))
+	}
+	if cnt < len(smallPrimes) || prev > limit || list[len(smallPrimes)-1] != smallPrimes[len(smallPrimes)-1] {
+		return fmt.Errorf("%w: inconsistent with its limit %d", ErrCorruptCache, limit)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit > s.limit {
+		s.primes, s.limit = list, limit
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package primes
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	orig := NewSieve()
+	expected := orig.Between(1, 1e6)
+
+	var buf bytes.Buffer
+	if err := orig.SaveCache(&buf); err != nil {
+		t.Fatalf("failed to save cache: %v", err)
+	}
+	data := buf.Bytes()
+
+	loaded := NewSieve()
+	if err := loaded.LoadCache(bytes.NewReader(data)); err != nil {
+		t.Fatalf("failed to load cache: %v", err)
+	}
+	if list, limit := loaded.cached(); !reflect.DeepEqual(list, expected) || limit < 1e6 {
+		t.Errorf("loaded cache doesn't match the saved one (%d primes up to %d)", len(list), limit)
+	}
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)/2] ^= 0x10
+	if err := NewSieve().LoadCache(bytes.NewReader(corrupt)); !errors.Is(err, ErrCorruptCache) {
+		t.Errorf("expected loading a corrupted cache to fail")
+	}
+	if err := NewSieve().LoadCache(bytes.NewReader(data[:len(data)-1])); !errors.Is(err, ErrCorruptCache) {
+		t.Errorf("expected loading a truncated cache to fail")
+	}
+}
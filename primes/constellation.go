@@ -0,0 +1,104 @@
+package primes
+
+import (
+	"fmt"
+	"iter"
+)
+
+// CheckPattern returns an error wrapping ErrInadmissible if the offsets aren't a valid prime
+// constellation pattern. The pattern has to start at 0 and be strictly ascending, and for every
+// prime p there has to be some residue mod p that none of the offsets cover, otherwise one of the
+// numbers in every match but finitely many would be divisible by p.
+func CheckPattern(pattern []int) error {
+	if len(pattern) == 0 || pattern[0] != 0 {
+		return fmt.Errorf("pattern %d doesn't start at 0: %w", pattern, ErrInadmissible)
+	}
+	for i := 1; i < len(pattern); i++ {
+		if pattern[i] <= pattern[i-1] {
+			return fmt.Errorf("pattern %d isn't strictly ascending: %w", pattern, ErrInadmissible)
+		}
+	}
+	// Only primes no bigger than the number of offsets can have all of their residues covered.
+	for _, prime := range Between(2, len(pattern)) {
+		covered := make([]bool, prime)
+		cnt := 0
+		for _, offset := range pattern {
+			if r := offset % prime; !covered[r] {
+				covered[r] = true
+				cnt++
+			}
+		}
+		if cnt == prime {
+			return fmt.Errorf("pattern %d covers every residue mod %d: %w", pattern, prime, ErrInadmissible)
+		}
+	}
+	return nil
+}
+
+// Constellations returns an iterator over every prime p in the range [lo, hi] such that p plus
+// each of the offsets in the pattern is also prime, in ascending order. For example the pattern
+// {0, 2, 6, 8} finds the prime quadruplets. It returns an error wrapping ErrInadmissible if the
+// pattern fails CheckPattern.
+func Constellations(pattern []int, lo, hi int) (iter.Seq[int], error) {
+	if err := CheckPattern(pattern); err != nil {
+		return nil, err
+	}
+	pattern = append([]int(nil), pattern...)
+	width := pattern[len(pattern)-1]
+	return func(yield func(int) bool) {
+		// The primes are streamed from a segmented sieve that runs width past hi, so every segment
+		// overlaps the next by the width of the pattern. A prime is only checked once every prime
+		// that could be part of its match has been buffered.
+		var buffer []int
+		check := func() bool {
+			first := buffer[0]
+			buffer = buffer[1:]
+			if first > hi {
+				return true
+			}
+			ind := 0
+			for _, offset := range pattern[1:] {
+				for ind < len(buffer) && buffer[ind] < first+offset {
+					ind++
+				}
+				if ind == len(buffer) || buffer[ind] != first+offset {
+					return true
+				}
+			}
+			return yield(first)
+		}
+
+		for prime := range Window(lo, hi+width) {
+			for len(buffer) > 0 && buffer[0]+width < prime {
+				if !check() {
+					return
+				}
+			}
+			buffer = append(buffer, prime)
+		}
+		for len(buffer) > 0 {
+			if !check() {
+				return
+			}
+		}
+	}, nil
+}
+
+// mustConstellations is Constellations for patterns that are known to be admissible.
+func mustConstellations(pattern []int, lo, hi int) iter.Seq[int] {
+	seq, err := Constellations(pattern, lo, hi)
+	if err != nil {
+		panic(err)
+	}
+	return seq
+}
+
+// TwinPrimes returns an iterator over every prime p in the range [lo, hi] where p+2 is also prime.
+func TwinPrimes(lo, hi int) iter.Seq[int] { return mustConstellations([]int{0, 2}, lo, hi) }
+
+// CousinPrimes returns an iterator over every prime p in the range [lo, hi] where p+4 is also
+// prime.
+func CousinPrimes(lo, hi int) iter.Seq[int] { return mustConstellations([]int{0, 4}, lo, hi) }
+
+// SexyPrimes returns an iterator over every prime p in the range [lo, hi] where p+6 is also prime.
+func SexyPrimes(lo, hi int) iter.Seq[int] { return mustConstellations([]int{0, 6}, lo, hi) }
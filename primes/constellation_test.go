@@ -0,0 +1,61 @@
+package primes
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCheckPattern(t *testing.T) {
+	valid := [][]int{{0}, {0, 2}, {0, 2, 6}, {0, 4, 6}, {0, 2, 6, 8}, {0, 2, 6, 8, 12}, {0, 4, 6, 10, 12, 16}}
+	for _, pattern := range valid {
+		if err := CheckPattern(pattern); err != nil {
+			t.Errorf("CheckPattern(%d) returned unexpected error %v", pattern, err)
+		}
+	}
+	invalid := [][]int{nil, {1, 3}, {0, 1}, {0, 2, 4}, {0, 6, 2}, {0, 2, 6, 8, 10}, {0, 0}}
+	for _, pattern := range invalid {
+		if err := CheckPattern(pattern); !errors.Is(err, ErrInadmissible) {
+			t.Errorf("CheckPattern(%d) returned error %v, expected ErrInadmissible", pattern, err)
+		}
+	}
+	if _, err := Constellations([]int{0, 2, 4}, 1, 100); !errors.Is(err, ErrInadmissible) {
+		t.Errorf("Constellations({0, 2, 4}) returned error %v, expected ErrInadmissible", err)
+	}
+}
+
+func TestConstellations(t *testing.T) {
+	if r := slices.Collect(TwinPrimes(1, 100)); !reflect.DeepEqual(r, []int{3, 5, 11, 17, 29, 41, 59, 71}) {
+		t.Errorf("TwinPrimes(1, 100) returned %d, expected [3,5,11,17,29,41,59,71]", r)
+	}
+	if r := slices.Collect(CousinPrimes(1, 50)); !reflect.DeepEqual(r, []int{3, 7, 13, 19, 37, 43}) {
+		t.Errorf("CousinPrimes(1, 50) returned %d, expected [3,7,13,19,37,43]", r)
+	}
+	if r := slices.Collect(SexyPrimes(1, 50)); !reflect.DeepEqual(r, []int{5, 7, 11, 13, 17, 23, 31, 37, 41, 47}) {
+		t.Errorf("SexyPrimes(1, 50) returned %d, expected [5,7,11,13,17,23,31,37,41,47]", r)
+	}
+
+	quads, err := Constellations([]int{0, 2, 6, 8}, 1, 2000)
+	if err != nil {
+		t.Fatalf("Constellations({0, 2, 6, 8}) returned unexpected error %v", err)
+	}
+	if r := slices.Collect(quads); !reflect.DeepEqual(r, []int{5, 11, 101, 191, 821, 1481, 1871}) {
+		t.Errorf("Constellations({0, 2, 6, 8}, 1, 2000) returned %d, expected [5,11,101,191,821,1481,1871]", r)
+	}
+
+	// The range only limits the first prime of each match, and spans many sieve segments.
+	cnt, last := 0, 0
+	for prime := range TwinPrimes(1e9, 1e9+1e7) {
+		if !IsPrime(prime) || !IsPrime(prime+2) {
+			t.Errorf("TwinPrimes returned %d, which isn't the start of a twin prime pair", prime)
+		}
+		cnt, last = cnt+1, prime
+	}
+	if cnt != 30704 {
+		t.Errorf("TwinPrimes(10^9, 10^9+10^7) returned %d pairs, expected 30704", cnt)
+	}
+	if r := slices.Collect(TwinPrimes(last, last)); !reflect.DeepEqual(r, []int{last}) {
+		t.Errorf("TwinPrimes(%d, %d) returned %d, expected [%d]", last, last, r, last)
+	}
+}
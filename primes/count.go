@@ -0,0 +1,68 @@
+package primes
+
+import "math"
+
+// nthPrimeSieveLimit is the largest bound NthPrime will expand the sieve to. Beyond that it
+// counts primes with PrimeCount and only sieves a small window around the answer.
+const nthPrimeSieveLimit = 1 << 24
+
+// PrimeCount returns the number of primes less than or equal to n.
+func PrimeCount(n int) int { return defaultSieve.PrimeCount(n) }
+
+// PrimeCount returns the number of primes less than or equal to n. Anything beyond what the sieve
+// has already cached is counted using Lucy_Hedgehog's algorithm, which takes roughly O(n^(3/4))
+// time and O(n^(1/2)) memory, so it can count the primes up to 10^12 in a couple of seconds.
+func (s *Sieve) PrimeCount(n int) int {
+	if n < 2 {
+		return 0
+	}
+	if list, limit := s.cached(); n <= limit {
+		return countUpTo(list, n)
+	}
+	return lucyCount(n)
+}
+
+// lucyCount counts the primes up to n by keeping track of how many numbers are left after sieving
+// each prime for every distinct value of n/i. The counts for values <= sqrt(n) are stored in small
+// (indexed by value) and those above in large (indexed by i).
+func lucyCount(n int) int {
+	r := ISqrt(n)
+	small := make([]int, r+1)
+	large := make([]int, r+1)
+	for v := 1; v <= r; v++ {
+		small[v] = v - 1
+		large[v] = n/v - 1
+	}
+
+	for p := 2; p <= r; p++ {
+		if small[p] == small[p-1] {
+			continue
+		}
+		cnt, sqr := small[p-1], p*p
+		for i := 1; i <= r && n/i >= sqr; i++ {
+			if d := i * p; d <= r {
+				large[i] -= large[d] - cnt
+			} else {
+				large[i] -= small[n/d] - cnt
+			}
+		}
+		for v := r; v >= sqr; v-- {
+			small[v] -= small[v/p] - cnt
+		}
+	}
+	return large[1]
+}
+
+// nthPrimeEstimate uses Cipolla's asymptotic expansion to guess the value of the nth prime. The
+// result is usually within a tiny fraction of a percent of the real value for large n.
+func nthPrimeEstimate(n int) int {
+	ln := math.Log(float64(n))
+	lnln := math.Log(ln)
+	return int(float64(n) * (ln + lnln - 1 + (lnln-2)/ln))
+}
+
+// nthPrimeBound returns an upper bound for the nth prime that holds for n >= 6.
+func nthPrimeBound(n int) int {
+	ln := math.Log(float64(n))
+	return int(float64(n)*(ln+math.Log(ln))) + 1
+}
@@ -0,0 +1,58 @@
+package primes
+
+import (
+	"testing"
+)
+
+func TestPrimeCount(t *testing.T) {
+	type s struct {
+		n, cnt int
+	}
+	expected := []s{
+		{1, 0},
+		{2, 1},
+		{30, 10},
+		{100, 25},
+		{7919, 1000},
+		{1e6, 78498},
+		{1e9, 50847534},
+	}
+	if !testing.Short() {
+		expected = append(expected, s{1e11, 4118054813})
+	}
+
+	for _, e := range expected {
+		if r := NewSieve().PrimeCount(e.n); r != e.cnt {
+			t.Errorf("PrimeCount(%d) returned %d, expected %d", e.n, r, e.cnt)
+		}
+	}
+}
+
+func TestNthPrime(t *testing.T) {
+	type s struct {
+		n, prime int
+	}
+	expected := []s{
+		{1, 2},
+		{10, 29},
+		{11, 31},
+		{10001, 104743},
+		{1e7, 179424673},
+		{1e8, 2038074743},
+	}
+	if !testing.Short() {
+		expected = append(expected, s{1e10, 252097800623})
+	}
+
+	for _, e := range expected {
+		if r := NewSieve().NthPrime(e.n); r != e.prime {
+			t.Errorf("NthPrime(%d) returned %d, expected %d", e.n, r, e.prime)
+		}
+		if r := NewSieve().PrimeIndex(e.prime); r != e.n {
+			t.Errorf("PrimeIndex(%d) returned %d, expected %d", e.prime, r, e.n)
+		}
+	}
+	if r := PrimeIndex(1e8); r != -1 {
+		t.Errorf("PrimeIndex(%d) returned %d, expected -1", int(1e8), r)
+	}
+}
@@ -0,0 +1,136 @@
+package primes
+
+import (
+	"container/heap"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// primePowers splits the prime factorization of num into a list of the distinct primes in
+// ascending order along with a parallel list of how many times each one appears.
+func (s *Sieve) primePowers(num int) (primes, counts []int) {
+	factors := s.FactorMap(num)
+	primes = slices.Sorted(maps.Keys(factors))
+	counts = make([]int, len(primes))
+	for i, prime := range primes {
+		counts[i] = factors[prime]
+	}
+	return primes, counts
+}
+
+// UnsortedDivisors returns an iterator over the divisors of num that are no bigger than limit, in
+// no particular order. If limit is negative every divisor is produced. It panics if num isn't a
+// natural number.
+func UnsortedDivisors(num, limit int) iter.Seq[int] { return defaultSieve.UnsortedDivisors(num, limit) }
+
+// UnsortedDivisors returns an iterator over the divisors of num that are no bigger than limit, in
+// no particular order. The divisors are generated depth first from the prime factorization, and
+// any branch whose product exceeds the limit is skipped entirely, so only the divisors actually
+// produced are ever computed.
+func (s *Sieve) UnsortedDivisors(num, limit int) iter.Seq[int] {
+	if limit < 0 || limit > num {
+		limit = num
+	}
+	primes, counts := s.primePowers(num)
+	return func(yield func(int) bool) {
+		if limit < 1 {
+			return
+		}
+		var walk func(ind, product int) bool
+		walk = func(ind, product int) bool {
+			if ind == len(primes) {
+				return yield(product)
+			}
+			for exp := 0; ; exp++ {
+				if !walk(ind+1, product) {
+					return false
+				}
+				if exp == counts[ind] || product > limit/primes[ind] {
+					return true
+				}
+				product *= primes[ind]
+			}
+		}
+		walk(0, 1)
+	}
+}
+
+// SortedDivisors returns an iterator over the divisors of num that are no bigger than limit, in
+// ascending order. If limit is negative every divisor is produced. It panics if num isn't a
+// natural number.
+func SortedDivisors(num, limit int) iter.Seq[int] { return defaultSieve.SortedDivisors(num, limit) }
+
+// SortedDivisors returns an iterator over the divisors of num that are no bigger than limit, in
+// ascending order. Rather than building and sorting every divisor it merges them with a heap:
+// each divisor d whose largest prime factor is p_i is the parent of d*p_j for every j >= i, which
+// generates every divisor exactly once and always after its parent.
+func (s *Sieve) SortedDivisors(num, limit int) iter.Seq[int] {
+	if limit < 0 || limit > num {
+		limit = num
+	}
+	primes, counts := s.primePowers(num)
+	return func(yield func(int) bool) {
+		if limit < 1 {
+			return
+		}
+		pending := productHeap{{value: 1}}
+		for len(pending) > 0 {
+			parent := heap.Pop(&pending).(productNode)
+			if !yield(parent.value) {
+				return
+			}
+			for j := parent.last; j < len(primes); j++ {
+				if parent.value > limit/primes[j] {
+					// The primes are in ascending order, so every later child would be too big too.
+					break
+				}
+				child := productNode{value: parent.value * primes[j], last: j, exp: 1}
+				if j == parent.last {
+					if parent.exp == counts[j] {
+						continue
+					}
+					child.exp = parent.exp + 1
+				}
+				heap.Push(&pending, child)
+			}
+		}
+	}
+}
+
+// DivisorPairs returns an iterator over the pairs of divisors (d, num/d) with d <= num/d, in
+// ascending order of d. It panics if num isn't a natural number.
+func DivisorPairs(num int) iter.Seq2[int, int] { return defaultSieve.DivisorPairs(num) }
+
+// DivisorPairs returns an iterator over the pairs of divisors (d, num/d) with d <= num/d, in
+// ascending order of d. Only the divisors up to the square root of num are ever generated.
+func (s *Sieve) DivisorPairs(num int) iter.Seq2[int, int] {
+	divisors := s.SortedDivisors(num, ISqrt(max(num, 0)))
+	return func(yield func(int, int) bool) {
+		for d := range divisors {
+			if !yield(d, num/d) {
+				return
+			}
+		}
+	}
+}
+
+// productNode is a product of primes from a list waiting in a productHeap, along with the index
+// of its largest prime factor in the list and how many times that prime divides it.
+type productNode struct {
+	value, last, exp int
+}
+
+// productHeap implements heap.Interface as a min-heap of products.
+type productHeap []productNode
+
+func (h productHeap) Len() int           { return len(h) }
+func (h productHeap) Less(i, j int) bool { return h[i].value < h[j].value }
+func (h productHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *productHeap) Push(x any)        { *h = append(*h, x.(productNode)) }
+func (h *productHeap) Pop() any {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return node
+}
@@ -0,0 +1,66 @@
+package primes
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSortedDivisors(t *testing.T) {
+	type s struct {
+		num, limit int
+		divisors   []int
+	}
+	expected := []s{
+		{1, -1, []int{1}},
+		{1, 0, nil},
+		{12, -1, []int{1, 2, 3, 4, 6, 12}},
+		{12, 5, []int{1, 2, 3, 4}},
+		{360, 20, []int{1, 2, 3, 4, 5, 6, 8, 9, 10, 12, 15, 18, 20}},
+		{1 << 20, 100, []int{1, 2, 4, 8, 16, 32, 64}},
+		{2147483647, -1, []int{1, 2147483647}},
+	}
+
+	for _, e := range expected {
+		if r := slices.Collect(SortedDivisors(e.num, e.limit)); !reflect.DeepEqual(r, e.divisors) {
+			t.Errorf("SortedDivisors(%d, %d) returned %d, expected %d", e.num, e.limit, r, e.divisors)
+		}
+		r := slices.Sorted(UnsortedDivisors(e.num, e.limit))
+		if !reflect.DeepEqual(r, e.divisors) {
+			t.Errorf("UnsortedDivisors(%d, %d) returned %d, expected %d", e.num, e.limit, r, e.divisors)
+		}
+	}
+
+	for _, num := range []int{720720, 963761198400, 6983776800} {
+		all := Divisors(num)
+		if r := slices.Collect(SortedDivisors(num, -1)); !reflect.DeepEqual(r, all) {
+			t.Errorf("SortedDivisors(%d, -1) doesn't match Divisors(%d)", num, num)
+		}
+		if r := slices.Sorted(UnsortedDivisors(num, -1)); !reflect.DeepEqual(r, all) {
+			t.Errorf("UnsortedDivisors(%d, -1) doesn't match Divisors(%d)", num, num)
+		}
+		limit := all[len(all)/3]
+		if r := slices.Collect(SortedDivisors(num, limit)); !reflect.DeepEqual(r, all[:len(all)/3+1]) {
+			t.Errorf("SortedDivisors(%d, %d) returned the wrong divisors", num, limit)
+		}
+	}
+}
+
+func TestDivisorPairs(t *testing.T) {
+	type pair struct{ a, b int }
+	var r []pair
+	for a, b := range DivisorPairs(36) {
+		r = append(r, pair{a, b})
+	}
+	if e := []pair{{1, 36}, {2, 18}, {3, 12}, {4, 9}, {6, 6}}; !reflect.DeepEqual(r, e) {
+		t.Errorf("DivisorPairs(36) returned %v, expected %v", r, e)
+	}
+
+	cnt := 0
+	for range DivisorPairs(963761198400) {
+		cnt++
+	}
+	if e := CountDivisors(963761198400) / 2; cnt != e {
+		t.Errorf("DivisorPairs(963761198400) returned %d pairs, expected %d", cnt, e)
+	}
+}
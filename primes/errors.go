@@ -0,0 +1,42 @@
+package primes
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrNotNatural is returned when a function that only works with natural numbers is given
+	// zero or a negative number.
+	ErrNotNatural = errors.New("not a natural number")
+	// ErrNotPrime is returned when a function that needs a prime modulus is given a number that
+	// isn't prime.
+	ErrNotPrime = errors.New("not a prime")
+	// ErrOverflow is returned when a result is too big to fit inside the type it's returned as.
+	ErrOverflow = errors.New("value overflows integer type")
+	// ErrNoInverse is returned when a number has no inverse under a modulus.
+	ErrNoInverse = errors.New("no modular inverse")
+	// ErrNoSolution is returned when a system of equations can't be solved.
+	ErrNoSolution = errors.New("no solution")
+	// ErrInadmissible is returned when a prime constellation pattern can't have infinitely many
+	// matches because its offsets cover every residue of some prime.
+	ErrInadmissible = errors.New("inadmissible constellation pattern")
+	// ErrCorruptCache is returned by LoadCache when the data isn't a valid saved prime cache.
+	ErrCorruptCache = errors.New("corrupt prime cache")
+)
+
+// CRTOverflowError is returned by CRT when the combined congruence doesn't fit in an int. It
+// holds the result calculated with big.Int instead, and wraps ErrOverflow.
+type CRTOverflowError struct {
+	Residue *big.Int
+	Modulus *big.Int
+}
+
+func (e *CRTOverflowError) Error() string {
+	return fmt.Sprintf("combined modulus %s doesn't fit in an int", e.Modulus)
+}
+
+func (e *CRTOverflowError) Unwrap() error {
+	return ErrOverflow
+}
@@ -0,0 +1,57 @@
+package primes
+
+import "fmt"
+
+// FactorialExponent returns the exponent of the prime p in the prime factorization of n!, which by
+// Legendre's formula is the sum of n/p^i over every power of p. For example the number of trailing
+// zeros of n! in base 10 is FactorialExponent(n, 5).
+func FactorialExponent(n, p int) int {
+	if p < 2 {
+		panic(fmt.Errorf("cannot use %d as a prime", p))
+	}
+	result := 0
+	for n >= p {
+		n /= p
+		result += n
+	}
+	return result
+}
+
+// FactorFactorial returns a map of how many times each prime appears in the prime factorization
+// of n!, without ever calculating n! itself. It panics if n is negative.
+func FactorFactorial(n int) map[int]int {
+	if n < 0 {
+		panic(fmt.Errorf("cannot take the factorial of %d: %w", n, ErrNotNatural))
+	}
+	result := map[int]int{}
+	for _, prime := range Between(2, n) {
+		result[prime] = FactorialExponent(n, prime)
+	}
+	return result
+}
+
+// FactorBinomial returns a map of how many times each prime appears in the prime factorization of
+// the binomial coefficient C(n, k), without ever calculating the coefficient. By Kummer's theorem
+// the exponent of p is the number of carries when adding k and n-k in base p, so only primes no
+// bigger than n can appear. It panics if k isn't in the range [0, n], where the coefficient is 0.
+func FactorBinomial(n, k int) map[int]int {
+	if k < 0 || k > n {
+		panic(fmt.Errorf("C(%d, %d) is 0: %w", n, k, ErrNotNatural))
+	}
+	result := map[int]int{}
+	if k == 0 || k == n {
+		return result
+	}
+	for _, prime := range Between(2, n) {
+		carries, carry := 0, 0
+		for a, b := k, n-k; a > 0 || b > 0; a, b = a/prime, b/prime {
+			if carry = (a%prime + b%prime + carry) / prime; carry > 0 {
+				carries++
+			}
+		}
+		if carries > 0 {
+			result[prime] = carries
+		}
+	}
+	return result
+}
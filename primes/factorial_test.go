@@ -0,0 +1,73 @@
+package primes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFactorialExponent(t *testing.T) {
+	type s struct {
+		n, p, r int
+	}
+	expected := []s{
+		{0, 2, 0},
+		{10, 2, 8},
+		{100, 5, 24},
+		{1000000000, 5, 249999998},
+		{1 << 40, 2, 1<<40 - 1},
+	}
+
+	for _, e := range expected {
+		if r := FactorialExponent(e.n, e.p); r != e.r {
+			t.Errorf("FactorialExponent(%d, %d) returned %d, expected %d", e.n, e.p, r, e.r)
+		}
+	}
+}
+
+func TestFactorFactorial(t *testing.T) {
+	if r := FactorFactorial(10); !reflect.DeepEqual(r, map[int]int{2: 8, 3: 4, 5: 2, 7: 1}) {
+		t.Errorf("FactorFactorial(10) returned %v, expected map[2:8 3:4 5:2 7:1]", r)
+	}
+	if r := FactorFactorial(1); len(r) != 0 {
+		t.Errorf("FactorFactorial(1) returned %v, expected an empty map", r)
+	}
+	fact := 1
+	for n := 2; n <= 20; n++ {
+		fact *= n
+		if r, e := FactorFactorial(n), FactorMap(fact); !reflect.DeepEqual(r, e) {
+			t.Errorf("FactorFactorial(%d) returned %v, expected %v", n, r, e)
+		}
+	}
+	if r := countDivisors(FactorFactorial(20)); r != CountDivisors(2432902008176640000) {
+		t.Errorf("countDivisors(FactorFactorial(20)) returned %d, expected %d", r, CountDivisors(2432902008176640000))
+	}
+}
+
+func TestFactorBinomial(t *testing.T) {
+	row := []int{1}
+	for n := 1; n <= 60; n++ {
+		next := make([]int, n+1)
+		next[0], next[n] = 1, 1
+		for k := 1; k < n; k++ {
+			next[k] = row[k-1] + row[k]
+		}
+		row = next
+		for k, c := range row {
+			r, e := FactorBinomial(n, k), FactorMap(c)
+			if len(r) != len(e) || (len(e) > 0 && !reflect.DeepEqual(r, e)) {
+				t.Errorf("FactorBinomial(%d, %d) returned %v, expected %v", n, k, r, e)
+			}
+		}
+	}
+
+	// C(2n, n) is divisible by every prime in (n, 2n] exactly once.
+	r := FactorBinomial(2000000, 1000000)
+	for _, prime := range Between(1000001, 2000000) {
+		if r[prime] != 1 {
+			t.Errorf("FactorBinomial(2000000, 1000000) has %d as the exponent of %d, expected 1", r[prime], prime)
+		}
+	}
+	if e := FactorialExponent(2000000, 2) - 2*FactorialExponent(1000000, 2); r[2] != e {
+		t.Errorf("FactorBinomial(2000000, 1000000) has %d as the exponent of 2, expected %d", r[2], e)
+	}
+}
@@ -2,86 +2,144 @@ package primes
 
 import (
 	"fmt"
-	"math"
-	"sort"
+	"math/bits"
+	"slices"
 )
 
-// Factor returns a slice of all of the prime factors for the given number.
-func Factor(orig int) []int {
+// Factor returns a slice of all of the prime factors for the given number in ascending order. It
+// panics if the number isn't a natural number.
+func Factor(num int) []int { return defaultSieve.Factor(num) }
+
+// TryFactor is like Factor, but returns an error wrapping ErrNotNatural instead of panicking.
+func TryFactor(num int) ([]int, error) { return defaultSieve.TryFactor(num) }
+
+// Factor is like TryFactor, but panics if the number can't be factored.
+func (s *Sieve) Factor(num int) []int {
+	result, err := s.TryFactor(num)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryFactor returns a slice of all of the prime factors for the given number in ascending order.
+// Only primes up to a small bound are found through trial division, after which the remaining
+// cofactor is split using Miller-Rabin and Pollard's rho. It returns an error wrapping
+// ErrNotNatural if the number isn't a natural number.
+func (s *Sieve) TryFactor(orig int) ([]int, error) {
 	if orig == 1 {
-		return []int{1}
+		return []int{1}, nil
 	}
 	if orig < 1 {
-		panic(fmt.Errorf("cannot factor non-natural number %d", orig))
+		return nil, fmt.Errorf("cannot factor %d: %w", orig, ErrNotNatural)
 	}
-	num := orig
-
 	result := []int{}
-	loopPrimes := func(list []int) {
-		for _, prime := range list {
-			if prime > num {
-				panic(fmt.Sprintf("factoring %d reached prime %d, but only %d remaining", orig, prime, num))
-			}
-			for num%prime == 0 {
-				result = append(result, prime)
-				num /= prime
-			}
-			// We've already removed all of the smaller prime factors, so if the number is bigger
-			// than the square of the current prime what's left must be a prime.
-			if num != 1 && prime*prime > num {
-				result = append(result, num)
-				num = 1
-			}
-			if num == 1 {
-				return
-			}
-		}
+	for _, prime := range s.factor64(uint64(orig)) {
+		result = append(result, int(prime))
 	}
+	return result, nil
+}
 
-	// Pull out all the factors of the primes we've already discovered before trying to expand the
-	// sieve. This helps limit how big we have to make the sieve when dealing with larger numbers.
-	loopPrimes(cachedSieve)
+// FactorOf is the generic version of Factor. It works for every value of unsigned types, including
+// the ones too big to fit in an int.
+func FactorOf[T Integer](num T) []T {
 	if num == 1 {
-		return result
+		return []T{1}
+	}
+	if num < 1 {
+		panic(fmt.Errorf("cannot factor %d: %w", num, ErrNotNatural))
+	}
+	result := []T{}
+	for _, prime := range defaultSieve.factor64(uint64(num)) {
+		result = append(result, T(prime))
+	}
+	return result
+}
+
+// factor64 returns the prime factors of num in ascending order. Only primes up to a small bound
+// are found through trial division, after which the remaining cofactor is split using
+// Miller-Rabin and Pollard's rho.
+func (s *Sieve) factor64(num uint64) []uint64 {
+	result := []uint64{}
+	for _, p := range s.expand(trialLimit) {
+		prime := uint64(p)
+		if prime > trialLimit || prime*prime > num {
+			break
+		}
+		for num%prime == 0 {
+			result = append(result, prime)
+			num /= prime
+		}
 	}
 
-	// Then loop through all of the primes between what we had cached and the square root of the
-	// number we haven't been able to reduce yet.
-	loopPrimes(Between(cachedRange, int(math.Sqrt(float64(num))+1)))
-	if num != 1 {
-		result = append(result, num)
+	// Anything left that's smaller than the square of the trial division limit can't have any
+	// factors we haven't already tried, so it must be prime.
+	if num < trialLimit*trialLimit {
+		if num != 1 {
+			result = append(result, num)
+		}
+		return result
 	}
 
+	result = appendRhoFactors(result, num)
+	slices.Sort(result)
 	return result
 }
 
 // FactorMap returns a map of how many times each factor appears in the prime factorization of
 // the given number. For example FactorMap(16) would return {2: 4} and FactorMap(60) would return
 // {2: 2, 3: 1, 5: 1}
-func FactorMap(num int) map[int]int {
+func FactorMap(num int) map[int]int { return defaultSieve.FactorMap(num) }
+
+// FactorMap returns a map of how many times each factor appears in the prime factorization of
+// the given number.
+func (s *Sieve) FactorMap(num int) map[int]int {
+	result, err := s.TryFactorMap(num)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryFactorMap is like FactorMap, but returns an error wrapping ErrNotNatural instead of panicking.
+func TryFactorMap(num int) (map[int]int, error) { return defaultSieve.TryFactorMap(num) }
+
+// TryFactorMap is like FactorMap, but returns an error wrapping ErrNotNatural instead of panicking.
+func (s *Sieve) TryFactorMap(num int) (map[int]int, error) {
 	if num == 1 {
-		return nil
+		return nil, nil
+	}
+	factors, err := s.TryFactor(num)
+	if err != nil {
+		return nil, err
 	}
-	factors := Factor(num)
 	result := map[int]int{}
 	for _, prime := range factors {
 		result[prime]++
 	}
-	return result
+	return result, nil
 }
 
 // CountDivisors counts the number of unique natural numbers >= 1 and that divide evenly into
 // the given number without a remainder (including the given number).
-func CountDivisors(num int) int {
+func CountDivisors(num int) int { return defaultSieve.CountDivisors(num) }
+
+// CountDivisors counts the number of unique natural numbers >= 1 and that divide evenly into
+// the given number without a remainder (including the given number).
+func (s *Sieve) CountDivisors(num int) int {
+	return countDivisors(s.FactorMap(num))
+}
+
+func countDivisors(factors map[int]int) int {
 	result := int(1)
-	for _, cnt := range FactorMap(num) {
+	for _, cnt := range factors {
 		result *= (cnt + 1)
 	}
 	return result
 }
 
-func multiplySlice(factor int, slice []int) []int {
-	result := make([]int, len(slice))
+func multiplySlice[T Integer](factor T, slice []T) []T {
+	result := make([]T, len(slice))
 	for i := range slice {
 		result[i] = factor * slice[i]
 	}
@@ -91,45 +149,149 @@ func multiplySlice(factor int, slice []int) []int {
 // Divisors finds all numbers that divide evenly into the provided number. Note that this does
 // require more work than determining how many there are, so only use this function if you need the
 // actual values of the divisors.
-func Divisors(num int) []int {
-	result := []int{1}
-	if num == 1 {
-		return result
+func Divisors(num int) []int { return defaultSieve.Divisors(num) }
+
+// Divisors finds all numbers that divide evenly into the provided number.
+func (s *Sieve) Divisors(num int) []int {
+	return divisors(s.FactorMap(num))
+}
+
+// DivisorsOf is the generic version of Divisors.
+func DivisorsOf[T Integer](num T) []T {
+	factors := map[T]int{}
+	for _, prime := range FactorOf(num) {
+		if prime != 1 {
+			factors[prime]++
+		}
 	}
+	return divisors(factors)
+}
 
-	for prime, cnt := range FactorMap(num) {
+func divisors[T Integer](factors map[T]int) []T {
+	result := []T{1}
+	for prime, cnt := range factors {
 		prev := result
-		for i, factor := int(1), prime; i <= cnt; i++ {
+		for i, factor := 1, prime; i <= cnt; i++ {
 			result = append(result, multiplySlice(factor, prev)...)
 			factor *= prime
 		}
 	}
-	sort.Ints(result)
+	slices.Sort(result)
 	return result
 }
 
 // EulerPhi calculates the number of positive integers less than n that are relatively prime to n.
-func EulerPhi(num int) int {
+func EulerPhi(num int) int { return defaultSieve.EulerPhi(num) }
+
+// EulerPhi calculates the number of positive integers less than n that are relatively prime to n.
+func (s *Sieve) EulerPhi(num int) int {
+	return eulerPhi(s.FactorMap(num))
+}
+
+func eulerPhi(factors map[int]int) int {
 	result := int(1)
-	for prime, cnt := range FactorMap(num) {
+	for prime, cnt := range factors {
 		result *= (prime - 1) * Pow(prime, cnt-1)
 	}
 	return result
 }
 
-// LCM returns the Least Common Multiple for the numbers specified
-func LCM(a, b int) int {
-	d := GCD(a, b)
-	return a * b / d
+// LCM returns the Least Common Multiple for the numbers specified. The result is never negative,
+// and it panics with an error wrapping ErrOverflow if the result doesn't fit in an int.
+func LCM(a, b int) int { return LCMOf(a, b) }
+
+// TryLCM is like LCM, but returns an error wrapping ErrOverflow instead of panicking.
+func TryLCM(a, b int) (int, error) { return TryLCMOf(a, b) }
+
+// LCMOf is the generic version of LCM.
+func LCMOf[T Integer](a, b T) T {
+	result, err := TryLCMOf(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryLCMOf is the generic version of TryLCM. It divides before multiplying, so it only overflows
+// if the result itself doesn't fit in the type.
+func TryLCMOf[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	x, y := absOf(a), absOf(b)
+	hi, lo := bits.Mul64(x/gcd64(x, y), y)
+	if result := T(lo); hi == 0 && result >= 0 && uint64(result) == lo {
+		return result, nil
+	}
+	return 0, fmt.Errorf("lcm(%d, %d) doesn't fit in %T: %w", a, b, a, ErrOverflow)
+}
+
+// LCMAll returns the Least Common Multiple of all the numbers specified, or 1 if there aren't
+// any. It returns an error wrapping ErrOverflow if the result doesn't fit in an int.
+func LCMAll(nums ...int) (int, error) {
+	if slices.Contains(nums, 0) {
+		return 0, nil
+	}
+	result := 1
+	for _, num := range nums {
+		var err error
+		if result, err = TryLCM(result, num); err != nil {
+			return 0, err
+		}
+	}
+	return result, nil
+}
+
+// LCMRange returns the Least Common Multiple of all the numbers from 1 to n, which is the product
+// of the largest power of each prime that is no bigger than n. It returns an error wrapping
+// ErrOverflow if the result doesn't fit in an int, which happens for any n above 42.
+func LCMRange(n int) (int, error) {
+	result := 1
+	for _, prime := range Between(2, n) {
+		power := prime
+		for power <= n/prime {
+			power *= prime
+		}
+		var ok bool
+		if result, ok = checkedMul(result, power); !ok {
+			return 0, fmt.Errorf("lcm(1..%d) doesn't fit in an int: %w", n, ErrOverflow)
+		}
+	}
+	return result, nil
+}
+
+// GCD return the Greatest Common Divisor for the numbers specified. Like big.Int.GCD the result
+// is never negative, GCD(a, 0) is |a|, and GCD(0, 0) is 0.
+func GCD(a, b int) int { return GCDOf(a, b) }
+
+// GCDOf is the generic version of GCD. The only result that can't be represented is the absolute
+// value of the smallest signed number, which can only happen if both numbers are either that
+// value or 0, in which case it panics with an error wrapping ErrOverflow.
+func GCDOf[T Integer](a, b T) T {
+	g := gcd64(absOf(a), absOf(b))
+	if result := T(g); result >= 0 {
+		return result
+	}
+	panic(fmt.Errorf("gcd(%d, %d) doesn't fit in %T: %w", a, b, a, ErrOverflow))
 }
 
-// GCD return the Greatest Common Divisor for the numbers specified
-func GCD(a, b int) int {
-	if a < b {
-		a, b = b, a
+// GCDAll returns the Greatest Common Divisor of all the numbers specified, or 0 if there aren't
+// any. It follows the same sign conventions as GCD.
+func GCDAll(nums ...int) int {
+	result := 0
+	for _, num := range nums {
+		if result = GCD(result, num); result == 1 {
+			break
+		}
 	}
-	for b > 0 {
-		a, b = b, a%b
+	return result
+}
+
+// absOf returns the absolute value of the number as a uint64, which works even for the smallest
+// signed number since negation wraps around to the right magnitude.
+func absOf[T Integer](num T) uint64 {
+	if num < 0 {
+		return -uint64(num)
 	}
-	return a
+	return uint64(num)
 }
@@ -1,6 +1,9 @@
 package primes
 
 import (
+	"errors"
+	"math"
+	"reflect"
 	"testing"
 )
 
@@ -11,6 +14,11 @@ func TestGCD(t *testing.T) {
 	expected := []s{
 		{8 * 5, 8 * 54, 8},
 		{27 * 7, 27 * 10, 27},
+		{-12, 18, 6},
+		{-12, -18, 6},
+		{0, -7, 7},
+		{0, 0, 0},
+		{math.MinInt, 6, 2},
 	}
 
 	for _, e := range expected {
@@ -29,6 +37,9 @@ func TestLCM(t *testing.T) {
 	}
 	expected := []s{
 		{26 * 72, 26 * 35, 26 * 72 * 35},
+		{-4, 6, 12},
+		{0, 5, 0},
+		{1 << 40 * 3, 1 << 40 * 5, 1 << 40 * 15},
 	}
 
 	for _, e := range expected {
@@ -40,3 +51,147 @@ func TestLCM(t *testing.T) {
 		}
 	}
 }
+
+func TestTryLCM(t *testing.T) {
+	if _, err := TryLCM(4294967311, 4294967291); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TryLCM(4294967311, 4294967291) returned error %v, expected ErrOverflow", err)
+	}
+	if _, err := TryLCM(math.MinInt, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TryLCM(MinInt, 1) returned error %v, expected ErrOverflow", err)
+	}
+	if r, err := TryLCM(math.MaxInt, math.MaxInt); err != nil || r != math.MaxInt {
+		t.Errorf("TryLCM(MaxInt, MaxInt) returned %d, %v, expected %d", r, err, math.MaxInt)
+	}
+	if _, err := TryLCMOf[uint8](16, 17); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TryLCMOf[uint8](16, 17) returned error %v, expected ErrOverflow", err)
+	}
+	if r, err := TryLCMOf[int8](-8, 12); err != nil || r != 24 {
+		t.Errorf("TryLCMOf[int8](-8, 12) returned %d, %v, expected 24", r, err)
+	}
+}
+
+func TestGCDAll(t *testing.T) {
+	type s struct {
+		nums []int
+		r    int
+	}
+	expected := []s{
+		{nil, 0},
+		{[]int{-9}, 9},
+		{[]int{12, 18, 30}, 6},
+		{[]int{0, 0, 15, -25}, 5},
+		{[]int{4, 9, 8}, 1},
+	}
+
+	for _, e := range expected {
+		if r := GCDAll(e.nums...); r != e.r {
+			t.Errorf("GCDAll(%d) returned %d, expected %d", e.nums, r, e.r)
+		}
+	}
+}
+
+func TestLCMAll(t *testing.T) {
+	type s struct {
+		nums []int
+		r    int
+	}
+	expected := []s{
+		{nil, 1},
+		{[]int{-9}, 9},
+		{[]int{4, 6, 10}, 60},
+		{[]int{1 << 62, 3, 0}, 0},
+	}
+
+	for _, e := range expected {
+		if r, err := LCMAll(e.nums...); err != nil || r != e.r {
+			t.Errorf("LCMAll(%d) returned %d, %v, expected %d", e.nums, r, err, e.r)
+		}
+	}
+	if _, err := LCMAll(1<<40, 3, 5, 7, 11, 13, 17, 19, 23); !errors.Is(err, ErrOverflow) {
+		t.Errorf("LCMAll(2^40, 3, 5, ..., 23) returned error %v, expected ErrOverflow", err)
+	}
+}
+
+func TestLCMRange(t *testing.T) {
+	type s struct {
+		n, r int
+	}
+	expected := []s{
+		{0, 1},
+		{1, 1},
+		{10, 2520},
+		{20, 232792560},
+		{42, 219060189739591200},
+	}
+
+	for _, e := range expected {
+		if r, err := LCMRange(e.n); err != nil || r != e.r {
+			t.Errorf("LCMRange(%d) returned %d, %v, expected %d", e.n, r, err, e.r)
+		}
+	}
+	if _, err := LCMRange(43); !errors.Is(err, ErrOverflow) {
+		t.Errorf("LCMRange(43) returned error %v, expected ErrOverflow", err)
+	}
+}
+
+func TestFactor(t *testing.T) {
+	type s struct {
+		num     int
+		factors []int
+	}
+	expected := []s{
+		{1, []int{1}},
+		{2, []int{2}},
+		{60, []int{2, 2, 3, 5}},
+		{1 << 62, nil},
+		{65537 * 65537, []int{65537, 65537}},
+		{1073741789 * 1073741827, []int{1073741789, 1073741827}},
+		{3037000453 * 3037000493, []int{3037000453, 3037000493}},
+		{2 * 2147483647 * 2147483629, []int{2, 2147483629, 2147483647}},
+		{9223372036854775783, []int{9223372036854775783}},
+	}
+	for i := 0; i < 62; i++ {
+		expected[3].factors = append(expected[3].factors, 2)
+	}
+
+	for _, e := range expected {
+		if r := NewSieve().Factor(e.num); !reflect.DeepEqual(r, e.factors) {
+			t.Errorf("Factor(%d) returned %d, expected %d", e.num, r, e.factors)
+		}
+	}
+}
+
+func TestTryFactor(t *testing.T) {
+	for _, num := range []int{0, -12} {
+		if _, err := TryFactor(num); !errors.Is(err, ErrNotNatural) {
+			t.Errorf("TryFactor(%d) returned error %v, expected ErrNotNatural", num, err)
+		}
+		if _, err := TryFactorMap(num); !errors.Is(err, ErrNotNatural) {
+			t.Errorf("TryFactorMap(%d) returned error %v, expected ErrNotNatural", num, err)
+		}
+	}
+	if r, err := TryFactor(12); err != nil || !reflect.DeepEqual(r, []int{2, 2, 3}) {
+		t.Errorf("TryFactor(12) returned %d, %v, expected [2,2,3]", r, err)
+	}
+}
+
+func TestGenericFactor(t *testing.T) {
+	if r := FactorOf[uint64](math.MaxUint64); !reflect.DeepEqual(r, []uint64{3, 5, 17, 257, 641, 65537, 6700417}) {
+		t.Errorf("FactorOf(2^64-1) returned %d, expected [3,5,17,257,641,65537,6700417]", r)
+	}
+	if r := FactorOf[uint64](18446744073709551557); !reflect.DeepEqual(r, []uint64{18446744073709551557}) {
+		t.Errorf("FactorOf(2^64-59) returned %d, expected [18446744073709551557]", r)
+	}
+	if r := DivisorsOf[uint8](255); !reflect.DeepEqual(r, []uint8{1, 3, 5, 15, 17, 51, 85, 255}) {
+		t.Errorf("DivisorsOf(uint8(255)) returned %d, expected [1,3,5,15,17,51,85,255]", r)
+	}
+	if r := DivisorsOf[int32](1); !reflect.DeepEqual(r, []int32{1}) {
+		t.Errorf("DivisorsOf(int32(1)) returned %d, expected [1]", r)
+	}
+	if r := LCMOf[int32](1<<20*3, 1<<20*5); r != 1<<20*15 {
+		t.Errorf("LCMOf[int32](3*2^20, 5*2^20) returned %d, expected %d", r, 1<<20*15)
+	}
+	if r := GCDOf[uint64](1<<63+1<<62, 1<<63); r != 1<<62 {
+		t.Errorf("GCDOf(3*2^62, 2^63) returned %d, expected %d", r, uint64(1<<62))
+	}
+}
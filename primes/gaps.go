@@ -0,0 +1,61 @@
+package primes
+
+import "iter"
+
+// gaps returns an iterator over every pair of consecutive primes in the range [lo, hi].
+func gaps(lo, hi int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		prev := 0
+		for prime := range Window(lo, hi) {
+			if prev != 0 && !yield(prev, prime) {
+				return
+			}
+			prev = prime
+		}
+	}
+}
+
+// MaximalGaps returns an iterator over the maximal prime gaps among the primes up to hi, which are
+// the gaps that are bigger than every gap before them. Each one is produced as the prime at the
+// start of the gap along with the size of the gap, so the first few are (2, 1), (3, 2), (7, 4) and
+// (23, 6).
+func MaximalGaps(hi int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		record := 0
+		for prime, next := range gaps(2, hi) {
+			if gap := next - prime; gap > record {
+				record = gap
+				if !yield(prime, gap) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FirstGap returns the first prime that is followed by a gap of exactly g, searching the primes
+// up to limit. The second value is false if no such gap was found.
+func FirstGap(g, limit int) (int, bool) {
+	// Every gap except the one after 2 is between two odd numbers.
+	if g == 1 && limit >= 3 {
+		return 2, true
+	} else if g < 2 || g%2 != 0 {
+		return 0, false
+	}
+	for prime, next := range gaps(3, limit) {
+		if next-prime == g {
+			return prime, true
+		}
+	}
+	return 0, false
+}
+
+// GapHistogram returns how many times each gap occurs between consecutive primes in the range
+// [lo, hi].
+func GapHistogram(lo, hi int) map[int]int {
+	result := map[int]int{}
+	for prime, next := range gaps(lo, hi) {
+		result[next-prime]++
+	}
+	return result
+}
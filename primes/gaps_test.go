@@ -0,0 +1,67 @@
+package primes
+
+import "testing"
+
+func TestMaximalGaps(t *testing.T) {
+	type gap struct{ prime, size int }
+	expected := []gap{{2, 1}, {3, 2}, {7, 4}, {23, 6}, {89, 8}, {113, 14}, {523, 18}, {887, 20},
+		{1129, 22}, {1327, 34}, {9551, 36}, {15683, 44}, {19609, 52}, {31397, 72}, {155921, 86},
+		{360653, 96}, {370261, 112}, {492113, 114}, {1349533, 118}, {1357201, 132}, {2010733, 148}}
+
+	var r []gap
+	for prime, size := range MaximalGaps(3e6) {
+		r = append(r, gap{prime, size})
+	}
+	if len(r) != len(expected) {
+		t.Fatalf("MaximalGaps(3*10^6) returned %d gaps, expected %d", len(r), len(expected))
+	}
+	for i := range r {
+		if r[i] != expected[i] {
+			t.Errorf("MaximalGaps(3*10^6) returned %v as gap %d, expected %v", r[i], i, expected[i])
+		}
+	}
+}
+
+func TestFirstGap(t *testing.T) {
+	type s struct {
+		g, limit, prime int
+		ok              bool
+	}
+	expected := []s{
+		{1, 10, 2, true},
+		{2, 10, 3, true},
+		{3, 1e6, 0, false},
+		{6, 100, 23, true},
+		{10, 1000, 139, true},
+		{14, 100, 0, false},
+		{14, 1000, 113, true},
+		{100, 1e6, 396733, true},
+	}
+
+	for _, e := range expected {
+		if prime, ok := FirstGap(e.g, e.limit); prime != e.prime || ok != e.ok {
+			t.Errorf("FirstGap(%d, %d) returned %d, %t, expected %d, %t", e.g, e.limit, prime, ok, e.prime, e.ok)
+		}
+	}
+}
+
+func TestGapHistogram(t *testing.T) {
+	hist := GapHistogram(2, 100)
+	if expected := map[int]int{1: 1, 2: 8, 4: 7, 6: 7, 8: 1}; len(hist) != len(expected) {
+		t.Errorf("GapHistogram(2, 100) returned %v, expected %v", hist, expected)
+	} else {
+		for gap, cnt := range expected {
+			if hist[gap] != cnt {
+				t.Errorf("GapHistogram(2, 100) has %d gaps of %d, expected %d", hist[gap], gap, cnt)
+			}
+		}
+	}
+
+	total := 0
+	for _, cnt := range GapHistogram(1e9, 1e9+1e7) {
+		total += cnt
+	}
+	if e := PrimeCount(1e9+1e7) - PrimeCount(1e9-1) - 1; total != e {
+		t.Errorf("GapHistogram(10^9, 10^9+10^7) counted %d gaps, expected %d", total, e)
+	}
+}
@@ -0,0 +1,100 @@
+package primes
+
+import (
+	"iter"
+	"sort"
+)
+
+// All returns an iterator over every prime in ascending order.
+func All() iter.Seq[int] { return defaultSieve.All() }
+
+// All returns an iterator over every prime in ascending order. The sieve is expanded one segment at
+// a time as the iteration goes beyond what has already been cached.
+func (s *Sieve) All() iter.Seq[int] { return s.From(2) }
+
+// From returns an iterator over every prime >= n in ascending order.
+func From(n int) iter.Seq[int] { return defaultSieve.From(n) }
+
+// From returns an iterator over every prime >= n in ascending order. If n is within the range the
+// sieve has cached the sieve is expanded one segment at a time as the iteration continues,
+// otherwise the primes are found one window at a time without caching anything but the primes
+// needed to sieve each window.
+func (s *Sieve) From(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		list, limit := s.cached()
+		if n > limit {
+			for lo := n; ; lo += segmentSpan {
+				for _, prime := range s.window(lo, lo+segmentSpan-1) {
+					if !yield(prime) {
+						return
+					}
+				}
+			}
+		}
+
+		for ind := sort.SearchInts(list, n); ; {
+			for ; ind < len(list); ind++ {
+				if !yield(list[ind]) {
+					return
+				}
+			}
+			s.expand(limit + segmentSpan)
+			list, limit = s.cached()
+		}
+	}
+}
+
+// DownFrom returns an iterator over every prime <= n in descending order.
+func DownFrom(n int) iter.Seq[int] { return defaultSieve.DownFrom(n) }
+
+// DownFrom returns an iterator over every prime <= n in descending order. Anything beyond what the
+// sieve has cached is found one window at a time without expanding the cache.
+func (s *Sieve) DownFrom(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		list, limit := s.cached()
+		for hi := n; hi > limit; hi -= segmentSpan {
+			lo := hi - segmentSpan + 1
+			if lo <= limit {
+				lo = limit + 1
+			}
+			primes := s.window(lo, hi)
+			for i := len(primes) - 1; i >= 0; i-- {
+				if !yield(primes[i]) {
+					return
+				}
+			}
+		}
+
+		for ind := countUpTo(list, n) - 1; ind >= 0; ind-- {
+			if !yield(list[ind]) {
+				return
+			}
+		}
+	}
+}
+
+// Window returns an iterator over every prime in the range [lo, hi] in ascending order.
+func Window(lo, hi int) iter.Seq[int] { return defaultSieve.Window(lo, hi) }
+
+// Window returns an iterator over every prime in the range [lo, hi] in ascending order. The range
+// is sieved one segment at a time, and the only primes that need to be cached are the ones up to
+// the square root of hi.
+func (s *Sieve) Window(lo, hi int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for start := lo; start <= hi; start += segmentSpan {
+			top := start + segmentSpan - 1
+			if top > hi || top < start {
+				top = hi
+			}
+			for _, prime := range s.window(start, top) {
+				if !yield(prime) {
+					return
+				}
+			}
+			// Stop explicitly rather than letting start overflow when hi is close to MaxInt.
+			if top == hi {
+				return
+			}
+		}
+	}
+}
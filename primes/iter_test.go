@@ -0,0 +1,98 @@
+package primes
+
+import (
+	"iter"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func collect(seq iter.Seq[int], cnt int) []int {
+	var result []int
+	for prime := range seq {
+		if len(result) >= cnt {
+			break
+		}
+		result = append(result, prime)
+	}
+	return result
+}
+
+func TestIterators(t *testing.T) {
+	s := NewSieve()
+	if list := collect(s.All(), 10); !reflect.DeepEqual(list, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}) {
+		t.Errorf("expected first 10 primes to be [2,3,...,29]; got %d", list)
+	}
+	if list := collect(s.All(), 1e5); list[len(list)-1] != 1299709 {
+		t.Errorf("expected 100000th prime to be 1299709; got %d", list[len(list)-1])
+	}
+	if list := collect(s.From(100), 5); !reflect.DeepEqual(list, []int{101, 103, 107, 109, 113}) {
+		t.Errorf("expected primes from 100 to start [101,103,107,109,113]; got %d", list)
+	}
+	if list := collect(s.From(1e12), 3); !reflect.DeepEqual(list, []int{1000000000039, 1000000000061, 1000000000063}) {
+		t.Errorf("expected primes from 10^12 to start [1000000000039,1000000000061,1000000000063]; got %d", list)
+	}
+	if list := collect(s.DownFrom(30), 20); !reflect.DeepEqual(list, []int{29, 23, 19, 17, 13, 11, 7, 5, 3, 2}) {
+		t.Errorf("expected primes down from 30 to be [29,23,...,2]; got %d", list)
+	}
+	if list := collect(s.DownFrom(1e12), 2); !reflect.DeepEqual(list, []int{999999999989, 999999999961}) {
+		t.Errorf("expected primes down from 10^12 to start [999999999989,999999999961]; got %d", list)
+	}
+
+	var cnt int
+	for prime := range s.Window(1e12, 1e12+1e6) {
+		if !s.IsPrime(prime) {
+			t.Errorf("Window returned non-prime %d", prime)
+		}
+		cnt++
+	}
+	if cnt != 36249 {
+		t.Errorf("expected 36249 primes between 10^12 and 10^12+10^6; got %d", cnt)
+	}
+}
+
+func TestWindowReuse(t *testing.T) {
+	seq := Window(5, 20)
+	for i := 0; i < 2; i++ {
+		if list := collect(seq, 10); !reflect.DeepEqual(list, []int{5, 7, 11, 13, 17, 19}) {
+			t.Errorf("expected pass %d over the window 5-20 to be [5,7,11,13,17,19]; got %d", i+1, list)
+		}
+	}
+}
+
+func TestWindowMaxInt(t *testing.T) {
+	// Sieving right up to MaxInt needs the primes up to 2^31.5, which is far too many to find in a
+	// test, so pretend they're cached. Sieving with only some of the primes can only leave extra
+	// numbers behind, so every real prime should still be found and the iteration has to end.
+	s := &Sieve{primes: Between(2, 1<<16), limit: 3037000500}
+	lo := math.MaxInt - 1000
+	found := map[int]bool{}
+	for num := range s.Window(lo, math.MaxInt) {
+		if num < lo {
+			t.Fatalf("window %d-MaxInt returned %d", lo, num)
+		}
+		found[num] = true
+	}
+	for num := lo; num > 0; num++ {
+		if isPrime64(uint64(num)) && !found[num] {
+			t.Errorf("window %d-MaxInt didn't return the prime %d", lo, num)
+		}
+	}
+}
+
+func TestMaxIntSearch(t *testing.T) {
+	// Pretend everything up to MaxInt has been sieved, so only the cached primes get searched.
+	s := &Sieve{primes: Between(2, 1000), limit: math.MaxInt}
+	if list := collect(s.DownFrom(math.MaxInt), 3); !reflect.DeepEqual(list, []int{997, 991, 983}) {
+		t.Errorf("expected primes down from MaxInt to start [997,991,983]; got %d", list)
+	}
+	if list := s.Between(980, math.MaxInt); !reflect.DeepEqual(list, []int{983, 991, 997}) {
+		t.Errorf("expected primes from 980-MaxInt to be [983,991,997]; got %d", list)
+	}
+	if cnt := s.PrimeCount(math.MaxInt); cnt != 168 {
+		t.Errorf("expected 168 cached primes up to MaxInt; got %d", cnt)
+	}
+	if list := collect(s.DownFrom(997), 2); !reflect.DeepEqual(list, []int{997, 991}) {
+		t.Errorf("expected primes down from 997 to start [997,991]; got %d", list)
+	}
+}
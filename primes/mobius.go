@@ -0,0 +1,86 @@
+package primes
+
+// Mobius returns the Möbius function μ(n): 0 if n is divisible by a square, otherwise -1 or 1
+// depending on whether it has an odd or even number of prime factors.
+func Mobius(n int) int {
+	result := 1
+	for _, cnt := range FactorMap(n) {
+		if cnt > 1 {
+			return 0
+		}
+		result = -result
+	}
+	return result
+}
+
+// MobiusTable returns the Möbius function for every number up to limit. It flips the sign of
+// every multiple of each prime and zeroes every multiple of its square, which only needs a single
+// byte of memory per number, unlike the general Multiplicative sieve.
+func MobiusTable(limit int) []int8 {
+	mu := make([]int8, limit+1)
+	for i := 1; i <= limit; i++ {
+		mu[i] = 1
+	}
+	for _, prime := range Between(2, limit) {
+		for mult := prime; mult <= limit; mult += prime {
+			mu[mult] = -mu[mult]
+		}
+		if sqr := prime * prime; sqr <= limit {
+			for mult := sqr; mult <= limit; mult += sqr {
+				mu[mult] = 0
+			}
+		}
+	}
+	return mu
+}
+
+// Mertens returns the Mertens function M(n), which is the sum of μ(k) for every k <= n. It takes
+// roughly O(n^(2/3)) time and memory by tabulating M for every number up to n^(2/3) and using the
+// identity sum(M(n/d) for d in 1..n) = 1 to work out the larger values, memoizing those along
+// the way since there are only O(sqrt(n)) distinct values of n/d.
+func Mertens(n int) int {
+	if n < 1 {
+		return 0
+	}
+	limit := ICbrt(n)
+	limit *= limit
+	if sqrt := ISqrt(n); limit <= sqrt {
+		limit = sqrt + 1
+	}
+	if limit > n {
+		limit = n
+	}
+
+	small := make([]int32, limit+1)
+	for i, mu := range MobiusTable(limit) {
+		if i > 0 {
+			small[i] = small[i-1] + int32(mu)
+		}
+	}
+
+	// Every value bigger than limit is n/k for a different k, so we can index the memo by k.
+	large := make([]int, n/limit+1)
+	done := make([]bool, n/limit+1)
+	var mertens func(x int) int
+	mertens = func(x int) int {
+		if x <= limit {
+			return int(small[x])
+		}
+		k := n / x
+		if done[k] {
+			return large[k]
+		}
+
+		// Group together all the d that have the same value of x/d.
+		result := 1
+		for d := 2; d <= x; {
+			q := x / d
+			next := x/q + 1
+			result -= (next - d) * mertens(q)
+			d = next
+		}
+		large[k], done[k] = result, true
+		return result
+	}
+	return mertens(n)
+}
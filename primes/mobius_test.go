@@ -0,0 +1,41 @@
+package primes
+
+import (
+	"testing"
+)
+
+func TestMobius(t *testing.T) {
+	mu := MobiusTable(1000)
+	for num := 1; num <= 1000; num++ {
+		if r := Mobius(num); r != int(mu[num]) {
+			t.Errorf("Mobius(%d) returned %d, but MobiusTable has %d", num, r, mu[num])
+		}
+	}
+	if mu[1] != 1 || mu[30] != -1 || mu[210] != 1 || mu[12] != 0 {
+		t.Errorf("MobiusTable returned the wrong values for 1, 30, 210, or 12")
+	}
+}
+
+func TestMertens(t *testing.T) {
+	type s struct {
+		n, m int
+	}
+	expected := []s{
+		{1, 1},
+		{10, -1},
+		{100, 1},
+		{1000, 2},
+		{1e4, -23},
+		{1e6, 212},
+		{1e9, -222},
+	}
+	if !testing.Short() {
+		expected = append(expected, s{1e11, -87856})
+	}
+
+	for _, e := range expected {
+		if r := Mertens(e.n); r != e.m {
+			t.Errorf("Mertens(%d) returned %d, expected %d", e.n, r, e.m)
+		}
+	}
+}
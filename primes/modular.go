@@ -0,0 +1,112 @@
+package primes
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Congruence represents the equation x ≡ Residue (mod Modulus).
+type Congruence struct {
+	Residue, Modulus int
+}
+
+// ExtGCD returns the greatest common divisor of a and b along with the Bézout coefficients x and
+// y such that a*x + b*y = g. Like big.Int.GCD the divisor is never negative, so like GCD it panics
+// with an error wrapping ErrOverflow in the one case where that can't be represented: when both
+// numbers are either math.MinInt or 0.
+func ExtGCD(a, b int) (g, x, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR == math.MinInt {
+		panic(fmt.Errorf("gcd(%d, %d) doesn't fit in an int: %w", a, b, ErrOverflow))
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// ModInverse returns the number x in the range [0, m) such that a*x ≡ 1 (mod m). It returns an
+// error wrapping ErrNoInverse if a and m aren't coprime.
+func ModInverse(a, m int) (int, error) {
+	if m < 1 {
+		return 0, fmt.Errorf("cannot use modulus %d: %w", m, ErrNotNatural)
+	}
+	g, x, _ := ExtGCD(int(reduceMod(a, m)), m)
+	if g != 1 {
+		return 0, fmt.Errorf("%d has no inverse mod %d: %w", a, m, ErrNoInverse)
+	}
+	return int(reduceMod(x, m)), nil
+}
+
+// CRT uses the Chinese Remainder Theorem to combine all of the congruences into a single one,
+// returning the smallest non-negative solution and the least common multiple of all the moduli.
+// The moduli don't need to be coprime, but if the congruences contradict each other it returns an
+// error wrapping ErrNoSolution. If the combined modulus is too big to fit in an int the system
+// is solved again with CRTBig, and the result is returned as a *CRTOverflowError, which wraps
+// ErrOverflow.
+func CRT(congruences ...Congruence) (residue, modulus int, err error) {
+	residue, modulus = 0, 1
+	for _, c := range congruences {
+		if c.Modulus < 1 {
+			return 0, 0, fmt.Errorf("cannot use modulus %d: %w", c.Modulus, ErrNotNatural)
+		}
+		r := int(reduceMod(c.Residue, c.Modulus))
+		g, inv, _ := ExtGCD(modulus, c.Modulus)
+		if (r-residue)%g != 0 {
+			return 0, 0, fmt.Errorf("x ≡ %d (mod %d) contradicts the previous congruences: %w", c.Residue, c.Modulus, ErrNoSolution)
+		}
+
+		step := c.Modulus / g
+		if modulus > math.MaxInt/step {
+			// Fall back to big.Int for the full combination, which also finds out if there is no
+			// solution at all rather than just that it doesn't fit.
+			bigResidue, bigModulus, err := CRTBig(congruences...)
+			if err != nil {
+				return 0, 0, err
+			}
+			return 0, 0, &CRTOverflowError{Residue: bigResidue, Modulus: bigModulus}
+		}
+
+		// residue + modulus*k ≡ r (mod c.Modulus) where k = (r-residue)/g * inv (mod step)
+		k := MulMod((r-residue)/g, inv, step)
+		residue += modulus * k
+		modulus *= step
+		residue = int(reduceMod(residue, modulus))
+	}
+	return residue, modulus, nil
+}
+
+// CRTBig is like CRT, but calculates the result using big.Int so it can't overflow.
+func CRTBig(congruences ...Congruence) (residue, modulus *big.Int, err error) {
+	residue, modulus = big.NewInt(0), big.NewInt(1)
+	var g, inv, r, m, diff, rem big.Int
+	for _, c := range congruences {
+		if c.Modulus < 1 {
+			return nil, nil, fmt.Errorf("cannot use modulus %d: %w", c.Modulus, ErrNotNatural)
+		}
+		m.SetInt64(int64(c.Modulus))
+		r.Mod(r.SetInt64(int64(c.Residue)), &m)
+		g.GCD(&inv, nil, modulus, &m)
+		diff.Sub(&r, residue)
+		if diff.QuoRem(&diff, &g, &rem); rem.Sign() != 0 {
+			return nil, nil, fmt.Errorf("x ≡ %d (mod %d) contradicts the previous congruences: %w", c.Residue, c.Modulus, ErrNoSolution)
+		}
+
+		m.Quo(&m, &g)
+		diff.Mul(&diff, &inv)
+		diff.Mod(&diff, &m)
+		residue.Add(residue, diff.Mul(&diff, modulus))
+		modulus.Mul(modulus, &m)
+		residue.Mod(residue, modulus)
+	}
+	return residue, modulus, nil
+}
@@ -0,0 +1,96 @@
+package primes
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestExtGCD(t *testing.T) {
+	type s struct {
+		a, b, g int
+	}
+	expected := []s{
+		{240, 46, 2},
+		{-240, 46, 2},
+		{17, -5, 1},
+		{0, -7, 7},
+		{0, 0, 0},
+	}
+
+	for _, e := range expected {
+		g, x, y := ExtGCD(e.a, e.b)
+		if g != e.g || e.a*x+e.b*y != g {
+			t.Errorf("ExtGCD(%d, %d) returned %d, %d, %d, expected gcd %d", e.a, e.b, g, x, y, e.g)
+		}
+	}
+
+	if g, x, y := ExtGCD(math.MinInt, 6); g != 2 || math.MinInt*x+6*y != 2 {
+		t.Errorf("ExtGCD(MinInt, 6) returned %d, %d, %d, expected gcd 2", g, x, y)
+	}
+	for _, b := range []int{0, math.MinInt} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrOverflow) {
+					t.Errorf("ExtGCD(MinInt, %d) panicked with %v, expected an error wrapping ErrOverflow", b, err)
+				}
+			}()
+			ExtGCD(math.MinInt, b)
+		}()
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	if r, err := ModInverse(3, 11); err != nil || r != 4 {
+		t.Errorf("ModInverse(3, 11) returned %d, %v, expected 4", r, err)
+	}
+	if r, err := ModInverse(-3, 11); err != nil || r != 7 {
+		t.Errorf("ModInverse(-3, 11) returned %d, %v, expected 7", r, err)
+	}
+	if r, err := ModInverse(1<<40, 1<<61-1); err != nil || MulMod(r, 1<<40, 1<<61-1) != 1 {
+		t.Errorf("ModInverse(2^40, 2^61-1) returned %d, %v, which isn't an inverse", r, err)
+	}
+	if _, err := ModInverse(6, 9); !errors.Is(err, ErrNoInverse) {
+		t.Errorf("ModInverse(6, 9) returned error %v, expected ErrNoInverse", err)
+	}
+}
+
+func TestCRT(t *testing.T) {
+	if r, m, err := CRT(Congruence{2, 3}, Congruence{3, 5}, Congruence{2, 7}); err != nil || r != 23 || m != 105 {
+		t.Errorf("CRT(2 mod 3, 3 mod 5, 2 mod 7) returned %d, %d, %v, expected 23, 105", r, m, err)
+	}
+	if r, m, err := CRT(Congruence{3, 4}, Congruence{5, 6}); err != nil || r != 11 || m != 12 {
+		t.Errorf("CRT(3 mod 4, 5 mod 6) returned %d, %d, %v, expected 11, 12", r, m, err)
+	}
+	if r, m, err := CRT(Congruence{-1, 1<<31 - 1}, Congruence{5, 1<<31 + 11}); err != nil || m != (1<<31-1)*(1<<31+11) ||
+		r%(1<<31-1) != 1<<31-2 || r%(1<<31+11) != 5 {
+		t.Errorf("CRT with large moduli returned %d, %d, %v", r, m, err)
+	}
+	if _, _, err := CRT(Congruence{1, 4}, Congruence{2, 6}); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("CRT(1 mod 4, 2 mod 6) returned error %v, expected ErrNoSolution", err)
+	}
+
+	huge := []Congruence{{1, 1<<61 - 1}, {2, 1<<31 - 1}, {3, 1000003}}
+	var overflow *CRTOverflowError
+	if _, _, err := CRT(huge...); !errors.Is(err, ErrOverflow) || !errors.As(err, &overflow) {
+		t.Errorf("CRT with huge moduli returned error %v, expected a *CRTOverflowError", err)
+	} else if overflow.Modulus.String() != "4951775010116142595269584002957891" {
+		t.Errorf("CRT with huge moduli returned %s as the big modulus, expected 4951775010116142595269584002957891", overflow.Modulus)
+	}
+	if _, _, err := CRT(append(huge, Congruence{1, 2}, Congruence{0, 4})...); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("CRT with huge inconsistent moduli returned error %v, expected ErrNoSolution", err)
+	}
+	r, m, err := CRTBig(huge...)
+	if err != nil || m.String() != "4951775010116142595269584002957891" {
+		t.Fatalf("CRTBig returned %v, %v, %v", r, m, err)
+	}
+	if overflow != nil && overflow.Residue.Cmp(r) != 0 {
+		t.Errorf("CRT with huge moduli returned %s as the big residue, expected %s", overflow.Residue, r)
+	}
+	for _, c := range huge {
+		if rem := new(big.Int).Mod(r, big.NewInt(int64(c.Modulus))); rem.Int64() != int64(c.Residue) {
+			t.Errorf("CRTBig result %s mod %d is %s, expected %d", r, c.Modulus, rem, c.Residue)
+		}
+	}
+}
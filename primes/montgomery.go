@@ -0,0 +1,91 @@
+package primes
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Montgomery holds the precomputed values needed to do modular multiplication under a single odd
+// modulus in Montgomery form, where a number a is represented as a*2^64 mod m. Multiplying in
+// that form replaces the expensive 128-bit division with a couple of multiplications, which pays
+// off when doing many multiplications under the same modulus. Values are converted into the form
+// with To and back out with From.
+type Montgomery struct {
+	m uint64
+	// inv is -m^-1 mod 2^64
+	inv uint64
+	// one is 2^64 mod m, which is 1 in Montgomery form
+	one uint64
+	// r2 is 2^128 mod m, used to convert numbers into Montgomery form
+	r2 uint64
+}
+
+// NewMontgomery creates a Montgomery context for the odd modulus m.
+func NewMontgomery(m uint64) Montgomery {
+	if m&1 == 0 || m < 3 {
+		panic(fmt.Errorf("Montgomery form requires an odd modulus > 1, got %d", m))
+	}
+	// Newton's method doubles the number of correct bits each iteration, and every odd number is
+	// its own inverse mod 8, so 5 iterations gets us all 64 bits.
+	inv := m
+	for i := 0; i < 5; i++ {
+		inv *= 2 - m*inv
+	}
+	one := -m % m
+	return Montgomery{m: m, inv: -inv, one: one, r2: mulMod64(one, one, m)}
+}
+
+// Modulus returns the modulus the context was created for.
+func (c Montgomery) Modulus() uint64 {
+	return c.m
+}
+
+// reduce returns (hi*2^64 + lo) * 2^-64 mod m, assuming the input is less than m*2^64.
+func (c Montgomery) reduce(hi, lo uint64) uint64 {
+	q := lo * c.inv
+	mhi, mlo := bits.Mul64(q, c.m)
+	_, carry := bits.Add64(lo, mlo, 0)
+	t, carry := bits.Add64(hi, mhi, carry)
+	if carry != 0 || t >= c.m {
+		t -= c.m
+	}
+	return t
+}
+
+// To converts a into Montgomery form.
+func (c Montgomery) To(a uint64) uint64 {
+	return c.Mul(a%c.m, c.r2)
+}
+
+// From converts a out of Montgomery form.
+func (c Montgomery) From(a uint64) uint64 {
+	return c.reduce(0, a)
+}
+
+// One returns 1 in Montgomery form.
+func (c Montgomery) One() uint64 {
+	return c.one
+}
+
+// Mul multiplies two numbers that are both in Montgomery form.
+func (c Montgomery) Mul(a, b uint64) uint64 {
+	return c.reduce(bits.Mul64(a, b))
+}
+
+// Pow raises a number in Montgomery form to the power b, returning the result in Montgomery form.
+func (c Montgomery) Pow(a, b uint64) uint64 {
+	p := c.one
+	for b > 0 {
+		if b&1 != 0 {
+			p = c.Mul(p, a)
+		}
+		b >>= 1
+		a = c.Mul(a, a)
+	}
+	return p
+}
+
+// PowMod returns (a^b)%m, handling the conversion into and out of Montgomery form.
+func (c Montgomery) PowMod(a, b uint64) uint64 {
+	return c.From(c.Pow(c.To(a), b))
+}
@@ -0,0 +1,45 @@
+package primes
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestMontgomery(t *testing.T) {
+	rnd := rand.New(rand.NewSource(13))
+	moduli := []uint64{3, 1e9 + 7, 1<<61 - 1, math.MaxUint64, math.MaxUint64 - 58}
+	for i := 0; i < 20; i++ {
+		moduli = append(moduli, rnd.Uint64()|1)
+	}
+
+	var e, m, r big.Int
+	for _, mod := range moduli {
+		mont := NewMontgomery(mod)
+		m.SetUint64(mod)
+		for i := 0; i < 20; i++ {
+			a, b := rnd.Uint64(), rnd.Uint64()
+			r.Exp(new(big.Int).SetUint64(a), e.SetUint64(b), &m)
+			if p := mont.PowMod(a, b); p != r.Uint64() {
+				t.Errorf("Montgomery(%d).PowMod(%d, %d) returned %d, expected %d", mod, a, b, p, r.Uint64())
+			}
+			if p := PowModOf(a, b, mod); p != r.Uint64() {
+				t.Errorf("PowModOf(%d, %d, %d) returned %d, expected %d", a, b, mod, p, r.Uint64())
+			}
+
+			r.Mul(new(big.Int).SetUint64(a), e.SetUint64(b))
+			r.Mod(&r, &m)
+			if p := mont.From(mont.Mul(mont.To(a), mont.To(b))); p != r.Uint64() {
+				t.Errorf("Montgomery(%d).Mul(%d, %d) returned %d, expected %d", mod, a, b, p, r.Uint64())
+			}
+			if p := MulModOf(a, b, mod); p != r.Uint64() {
+				t.Errorf("MulModOf(%d, %d, %d) returned %d, expected %d", a, b, mod, p, r.Uint64())
+			}
+		}
+	}
+
+	if r := MulMod(-3, 1<<62, 1<<62+1); r != 3 {
+		t.Errorf("MulMod(-3, 2^62, 2^62+1) returned %d, expected 3", r)
+	}
+}
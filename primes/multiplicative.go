@@ -0,0 +1,83 @@
+package primes
+
+// Multiplicative returns a table with the value of a multiplicative function for every number up
+// to limit. The function is defined by its value at prime powers, with atPower(p, k) returning
+// f(p^k), and f(1) is always 1. The table is filled in linear time using the sieve of Euler, and
+// atPower is only called once for each prime power up to the limit.
+func Multiplicative[T Number](limit int, atPower func(prime, exp int) T) []T {
+	values := make([]T, limit+1)
+	if limit < 1 {
+		return values
+	}
+	values[1] = 1
+
+	// For every number we keep track of the largest power of its smallest prime factor that divides
+	// it, along with the exponent of that power.
+	lowPower := make([]int, limit+1)
+	lowExp := make([]uint8, limit+1)
+	var primes []int
+	for i := 2; i <= limit; i++ {
+		if lowPower[i] == 0 {
+			primes = append(primes, i)
+			lowPower[i], lowExp[i] = i, 1
+			values[i] = atPower(i, 1)
+		}
+		for _, prime := range primes {
+			num := i * prime
+			if num > limit {
+				break
+			}
+			if i%prime != 0 {
+				lowPower[num], lowExp[num] = prime, 1
+				values[num] = values[i] * values[prime]
+				continue
+			}
+
+			lowPower[num], lowExp[num] = lowPower[i]*prime, lowExp[i]+1
+			if lowPower[num] == num {
+				values[num] = atPower(prime, int(lowExp[num]))
+			} else {
+				values[num] = values[num/lowPower[num]] * values[lowPower[num]]
+			}
+			break
+		}
+	}
+	return values
+}
+
+// PhiTable returns Euler's totient function for every number up to limit.
+func PhiTable(limit int) []int {
+	return Multiplicative(limit, func(prime, exp int) int {
+		return (prime - 1) * Pow(prime, exp-1)
+	})
+}
+
+// SigmaTable returns the sum of the kth powers of the divisors for every number up to limit.
+// SigmaTable(limit, 1) is the sum of divisors, and SigmaTable(limit, 0) is the number of divisors.
+// The sums aren't checked for overflow. That can't happen for k <= 1 with any table that fits in
+// memory, but for k >= 2 the sum for n is just under ζ(k)*n^k < 2*n^k, so 2*limit^k has to fit in an
+// int or the largest values will wrap around. Use TrySigmaRange when that isn't guaranteed.
+func SigmaTable(limit, k int) []int {
+	return Multiplicative(limit, func(prime, exp int) int {
+		sum, term, step := 1, 1, Pow(prime, k)
+		for i := 0; i < exp; i++ {
+			term *= step
+			sum += term
+		}
+		return sum
+	})
+}
+
+// DivisorCountTable returns the number of divisors for every number up to limit.
+func DivisorCountTable(limit int) []int {
+	return Multiplicative(limit, func(prime, exp int) int {
+		return exp + 1
+	})
+}
+
+// RadicalTable returns the product of the distinct prime factors for every number up to limit.
+func RadicalTable(limit int) []int {
+	return Multiplicative(limit, func(prime, exp int) int {
+		return prime
+	})
+}
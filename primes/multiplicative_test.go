@@ -0,0 +1,48 @@
+package primes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiplicative(t *testing.T) {
+	const limit = 3000
+	phi, mu, sigma, d, rad := PhiTable(limit), MobiusTable(limit), SigmaTable(limit, 1), DivisorCountTable(limit), RadicalTable(limit)
+	for num := 1; num <= limit; num++ {
+		if r, e := phi[num], EulerPhi(num); r != e {
+			t.Errorf("PhiTable[%d] returned %d, expected %d", num, r, e)
+		}
+		if r, e := d[num], CountDivisors(num); r != e {
+			t.Errorf("DivisorCountTable[%d] returned %d, expected %d", num, r, e)
+		}
+
+		var sum int
+		for _, div := range Divisors(num) {
+			sum += div
+		}
+		if r := sigma[num]; r != sum {
+			t.Errorf("SigmaTable[%d] returned %d, expected %d", num, r, sum)
+		}
+
+		expMu, expRad := int8(1), 1
+		for prime, cnt := range FactorMap(num) {
+			expMu, expRad = -expMu, expRad*prime
+			if cnt > 1 {
+				expMu = 0
+			}
+		}
+		if r := mu[num]; r != expMu {
+			t.Errorf("MobiusTable[%d] returned %d, expected %d", num, r, expMu)
+		}
+		if r := rad[num]; r != expRad {
+			t.Errorf("RadicalTable[%d] returned %d, expected %d", num, r, expRad)
+		}
+	}
+
+	if r := SigmaTable(12, 2); !reflect.DeepEqual(r[10:], []int{130, 122, 210}) {
+		t.Errorf("SigmaTable(12, 2)[10:] returned %d, expected [130,122,210]", r[10:])
+	}
+	if r := Multiplicative(6, func(prime, exp int) float64 { return 1 / float64(prime) }); r[6] != 1.0/6 {
+		t.Errorf("Multiplicative with float values returned %g for 6, expected %g", r[6], 1.0/6)
+	}
+}
@@ -0,0 +1,177 @@
+package primes
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CarmichaelLambda returns the smallest positive number m such that a^m ≡ 1 (mod n) for every a
+// coprime with n. It always divides EulerPhi(n), and is the order of the primitive roots of n
+// when they exist.
+func CarmichaelLambda(n int) int {
+	result := 1
+	for prime, cnt := range FactorMap(n) {
+		lambda := (prime - 1) * Pow(prime, cnt-1)
+		if prime == 2 && cnt >= 3 {
+			lambda /= 2
+		}
+		result = LCM(result, lambda)
+	}
+	return result
+}
+
+// MultiplicativeOrder returns the smallest positive number k such that a^k ≡ 1 (mod n). It
+// starts from CarmichaelLambda(n), which every order divides, and removes prime factors for as
+// long as the power stays 1. It returns an error wrapping ErrNoInverse if a and n aren't coprime,
+// since a then has no order.
+func MultiplicativeOrder(a, n int) (int, error) {
+	if n < 1 {
+		return 0, fmt.Errorf("cannot use modulus %d: %w", n, ErrNotNatural)
+	}
+	if n == 1 {
+		return 1, nil
+	}
+	if GCD(int(reduceMod(a, n)), n) != 1 {
+		return 0, fmt.Errorf("%d has no order mod %d: %w", a, n, ErrNoInverse)
+	}
+
+	order := CarmichaelLambda(n)
+	for prime := range FactorMap(order) {
+		for order%prime == 0 && PowMod(a, order/prime, n) == 1 {
+			order /= prime
+		}
+	}
+	return order, nil
+}
+
+// PrimitiveRoot returns the smallest primitive root of n, which is a number whose powers generate
+// every number coprime with n. Only 1, 2, 4, p^k and 2p^k (for odd primes p) have primitive roots,
+// for everything else it returns an error wrapping ErrNoSolution.
+func PrimitiveRoot(n int) (int, error) {
+	if n < 1 {
+		return 0, fmt.Errorf("cannot use modulus %d: %w", n, ErrNotNatural)
+	}
+	if n <= 4 {
+		return n - 1, nil
+	}
+
+	factors := FactorMap(n)
+	odd := n
+	if factors[2] == 1 {
+		odd /= 2
+	}
+	if factors[2] > 1 || len(FactorMap(odd)) != 1 {
+		return 0, fmt.Errorf("%d has no primitive roots: %w", n, ErrNoSolution)
+	}
+
+	phi := eulerPhi(factors)
+	phiFactors := FactorMap(phi)
+	for g := 2; g < n; g++ {
+		if GCD(g, n) != 1 {
+			continue
+		}
+		generator := true
+		for prime := range phiFactors {
+			if PowMod(g, phi/prime, n) == 1 {
+				generator = false
+				break
+			}
+		}
+		if generator {
+			return g, nil
+		}
+	}
+	return 0, fmt.Errorf("failed to find primitive root of %d: %w", n, ErrNoSolution)
+}
+
+// DiscreteLog returns the smallest non-negative number x such that g^x ≡ h (mod n), where g must
+// be coprime with n. It uses the Pohlig-Hellman algorithm to split the problem into one for each
+// prime factor of the order of g, solving each of those with baby-step giant-step, so the time it
+// takes depends on the square root of the largest prime factor of the order rather than n itself.
+// It returns an error wrapping ErrNoSolution if h isn't a power of g.
+func DiscreteLog(g, h, n int) (int, error) {
+	order, err := MultiplicativeOrder(g, n)
+	if err != nil {
+		return 0, err
+	}
+	h = int(reduceMod(h, n))
+	if n == 1 {
+		return 0, nil
+	}
+
+	ginv, err := ModInverse(g, n)
+	if err != nil {
+		return 0, err
+	}
+
+	// Go through the prime factors in a consistent order so the errors are deterministic.
+	orderFactors := FactorMap(order)
+	primes := make([]int, 0, len(orderFactors))
+	for prime := range orderFactors {
+		primes = append(primes, prime)
+	}
+	sort.Ints(primes)
+
+	congruences := make([]Congruence, 0, len(primes))
+	for _, prime := range primes {
+		// Move everything into the subgroup of order prime^cnt, then find x mod prime^cnt one
+		// base-prime digit at a time, with each digit being a discrete log in the subgroup of
+		// order prime.
+		cnt := orderFactors[prime]
+		pk := Pow(prime, cnt)
+		gi, giInv, hi := PowMod(g, order/pk, n), PowMod(ginv, order/pk, n), PowMod(h, order/pk, n)
+		gamma := PowMod(gi, pk/prime, n)
+
+		x, digit := 0, 1
+		for k := 0; k < cnt; k++ {
+			hk := MulMod(PowMod(giInv, x, n), hi, n)
+			hk = PowMod(hk, pk/prime/digit, n)
+			d, ok := babyGiant(gamma, hk, n, prime)
+			if !ok {
+				return 0, fmt.Errorf("%d is not a power of %d mod %d: %w", h, g, n, ErrNoSolution)
+			}
+			x += d * digit
+			digit *= prime
+		}
+		congruences = append(congruences, Congruence{Residue: x, Modulus: pk})
+	}
+
+	x, _, err := CRT(congruences...)
+	if err != nil {
+		return 0, err
+	}
+	if PowMod(g, x, n) != h {
+		return 0, fmt.Errorf("%d is not a power of %d mod %d: %w", h, g, n, ErrNoSolution)
+	}
+	return x, nil
+}
+
+// babyGiant uses the baby-step giant-step algorithm to find x in [0, order) such that
+// g^x ≡ h (mod n), where order is the multiplicative order of g.
+func babyGiant(g, h, n, order int) (int, bool) {
+	m := ISqrt(order)
+	if m*m < order {
+		m++
+	}
+
+	baby := make(map[int]int, m)
+	for j, val := 0, 1; j < m; j++ {
+		if _, ok := baby[val]; !ok {
+			baby[val] = j
+		}
+		val = MulMod(val, g, n)
+	}
+
+	ginv, err := ModInverse(g, n)
+	if err != nil {
+		return 0, false
+	}
+	giant := PowMod(ginv, m, n)
+	for i, val := 0, h; i < m; i++ {
+		if j, ok := baby[val]; ok {
+			return i*m + j, true
+		}
+		val = MulMod(val, giant, n)
+	}
+	return 0, false
+}
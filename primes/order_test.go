@@ -0,0 +1,81 @@
+package primes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultiplicativeOrder(t *testing.T) {
+	type s struct {
+		a, n, order int
+	}
+	expected := []s{
+		{2, 1, 1},
+		{10, 7, 6},
+		{10, 983, 982},
+		{3, 1 << 10, 256},
+		{10, 999999999989, 999999999988},
+		{2, 1<<61 - 1, 61},
+	}
+
+	for _, e := range expected {
+		if r, err := MultiplicativeOrder(e.a, e.n); err != nil || r != e.order {
+			t.Errorf("MultiplicativeOrder(%d, %d) returned %d, %v, expected %d", e.a, e.n, r, err, e.order)
+		}
+	}
+	if _, err := MultiplicativeOrder(10, 12); !errors.Is(err, ErrNoInverse) {
+		t.Errorf("MultiplicativeOrder(10, 12) returned error %v, expected ErrNoInverse", err)
+	}
+	if r := CarmichaelLambda(561); r != 80 {
+		t.Errorf("CarmichaelLambda(561) returned %d, expected 80", r)
+	}
+}
+
+func TestPrimitiveRoot(t *testing.T) {
+	type s struct {
+		n, root int
+	}
+	expected := []s{
+		{1, 0},
+		{2, 1},
+		{4, 3},
+		{7, 3},
+		{23, 5},
+		{49, 3},
+		{2 * 243, 5},
+		{1e9 + 7, 5},
+	}
+
+	for _, e := range expected {
+		if r, err := PrimitiveRoot(e.n); err != nil || r != e.root {
+			t.Errorf("PrimitiveRoot(%d) returned %d, %v, expected %d", e.n, r, err, e.root)
+		}
+	}
+	for _, n := range []int{8, 15, 4 * 9} {
+		if _, err := PrimitiveRoot(n); !errors.Is(err, ErrNoSolution) {
+			t.Errorf("PrimitiveRoot(%d) returned error %v, expected ErrNoSolution", n, err)
+		}
+	}
+}
+
+func TestDiscreteLog(t *testing.T) {
+	type s struct {
+		g, h, n, x int
+	}
+	expected := []s{
+		{3, 13, 17, 4},
+		{2, 1, 11, 0},
+		{5, 372224738, 1e9 + 7, 123456789},
+		{5, 480192896733574989, 998244359987710471, 123456789},
+		{7, 1669094177198130885, 1<<61 - 1, 987654321987},
+	}
+
+	for _, e := range expected {
+		if r, err := DiscreteLog(e.g, e.h, e.n); err != nil || r != e.x {
+			t.Errorf("DiscreteLog(%d, %d, %d) returned %d, %v, expected %d", e.g, e.h, e.n, r, err, e.x)
+		}
+	}
+	if _, err := DiscreteLog(2, 3, 7); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("DiscreteLog(2, 3, 7) returned error %v, expected ErrNoSolution", err)
+	}
+}
@@ -0,0 +1,70 @@
+package primes
+
+import "math/bits"
+
+// millerRabinBases is a set of bases that makes the Miller-Rabin test deterministic for every
+// 64-bit number (found by Jim Sinclair).
+var millerRabinBases = []uint64{2, 325, 9375, 28178, 450775, 9780504, 1795265022}
+
+// mulMod64 returns (a*b)%m using the full 128-bit product so it never overflows. Both a and b
+// must already be less than m.
+func mulMod64(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, m)
+	return rem
+}
+
+// powMod64 returns (a^b)%m without ever overflowing any of the intermediate products.
+func powMod64(a, b, m uint64) uint64 {
+	if m&1 != 0 && m > 1 {
+		return NewMontgomery(m).PowMod(a, b)
+	}
+	p := uint64(1) % m
+	a %= m
+	for b > 0 {
+		if b&1 != 0 {
+			p = mulMod64(p, a, m)
+		}
+		b >>= 1
+		a = mulMod64(a, a, m)
+	}
+	return p
+}
+
+// isPrime64 uses the deterministic Miller-Rabin test to check if a number is prime. It uses a
+// constant amount of memory no matter how large the number is.
+func isPrime64(num uint64) bool {
+	if num < 2 {
+		return false
+	}
+	for _, prime := range smallPrimes {
+		if num%uint64(prime) == 0 {
+			return num == uint64(prime)
+		}
+	}
+
+	// Write num-1 as d*2^r with d odd. All of the work is done in Montgomery form, where 1 and -1
+	// have different representations than normal.
+	r := bits.TrailingZeros64(num - 1)
+	d := (num - 1) >> r
+	mont := NewMontgomery(num)
+	one, minusOne := mont.One(), num-mont.One()
+	for _, base := range millerRabinBases {
+		if base %= num; base == 0 {
+			continue
+		}
+		x := mont.Pow(mont.To(base), d)
+		if x == one || x == minusOne {
+			continue
+		}
+		witness := true
+		for i := 1; i < r && witness; i++ {
+			x = mont.Mul(x, x)
+			witness = x != minusOne
+		}
+		if witness {
+			return false
+		}
+	}
+	return true
+}
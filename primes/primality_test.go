@@ -0,0 +1,51 @@
+package primes
+
+import (
+	"testing"
+)
+
+func TestIsPrime(t *testing.T) {
+	type s struct {
+		num   int
+		prime bool
+	}
+	expected := []s{
+		{-7, false},
+		{0, false},
+		{1, false},
+		{2, true},
+		{561, false},
+		{7919, true},
+		{3215031751, false},
+		{2147483647, true},
+		{341550071728321, false},
+		{1000000000000037, true},
+		{3825123056546413051, false},
+		{1<<61 - 1, true},
+		{(1<<31 - 1) * (1<<31 - 1), false},
+		{9223372036854775783, true},
+	}
+
+	for _, e := range expected {
+		if r := NewSieve().IsPrime(e.num); r != e.prime {
+			t.Errorf("IsPrime(%d) returned %t, expected %t", e.num, r, e.prime)
+		}
+	}
+}
+
+func TestPowMod64(t *testing.T) {
+	type s struct {
+		a, b, m, r uint64
+	}
+	expected := []s{
+		{2, 10, 1000, 24},
+		{3, 0, 1, 0},
+		{1<<63 + 5, 2, 1<<64 - 59, 13835058055282164858},
+	}
+
+	for _, e := range expected {
+		if r := powMod64(e.a, e.b, e.m); r != e.r {
+			t.Errorf("powMod64(%d, %d, %d) returned %d, expected %d", e.a, e.b, e.m, r, e.r)
+		}
+	}
+}
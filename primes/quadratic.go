@@ -0,0 +1,258 @@
+package primes
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// Jacobi returns the Jacobi symbol (a/n) for odd positive n, which is the product of the Legendre
+// symbols for each prime factor of n. It is calculated using quadratic reciprocity, so it doesn't
+// need to factor n.
+func Jacobi(a, n int) int {
+	if n < 1 || n%2 == 0 {
+		panic(fmt.Errorf("the Jacobi symbol is only defined for odd positive numbers, not %d", n))
+	}
+	a = int(reduceMod(a, n))
+	result := 1
+	for a != 0 {
+		for a%2 == 0 {
+			a /= 2
+			if r := n % 8; r == 3 || r == 5 {
+				result = -result
+			}
+		}
+		a, n = n, a
+		if a%4 == 3 && n%4 == 3 {
+			result = -result
+		}
+		a %= n
+	}
+	if n != 1 {
+		return 0
+	}
+	return result
+}
+
+// Legendre returns the Legendre symbol (a/p) for an odd prime p: 1 if a is a quadratic residue
+// mod p, -1 if it's not, and 0 if p divides a.
+func Legendre(a, p int) int {
+	return Jacobi(a, p)
+}
+
+// addMod returns (a+b)%m for a and b in [0, m) without overflowing.
+func addMod(a, b, m int) int {
+	if a >= m-b {
+		return a - (m - b)
+	}
+	return a + b
+}
+
+// SqrtModPrime returns the smallest x such that x^2 ≡ a (mod p) for a prime p. The other root is
+// p-x. It returns an error wrapping ErrNoSolution if a isn't a quadratic residue. Primes where p-1
+// is divisible by a large power of 2 use Cipolla's algorithm, while the rest use Tonelli-Shanks,
+// which is faster when the power of 2 is small. It returns an error wrapping ErrNotPrime if p
+// isn't prime.
+func SqrtModPrime(a, p int) (int, error) {
+	if !IsPrime(p) {
+		return 0, fmt.Errorf("cannot use modulus %d: %w", p, ErrNotPrime)
+	}
+	a = int(reduceMod(a, p))
+	if p == 2 || a == 0 {
+		return a, nil
+	}
+	if Legendre(a, p) != 1 {
+		return 0, fmt.Errorf("%d is not a quadratic residue mod %d: %w", a, p, ErrNoSolution)
+	}
+
+	var root int
+	if twos := bits.TrailingZeros64(uint64(p - 1)); twos == 1 {
+		root = PowMod(a, (p+1)/4, p)
+	} else if twos <= 8 {
+		root = tonelliShanks(a, p, twos)
+	} else {
+		root = cipolla(a, p)
+	}
+	return min(root, p-root), nil
+}
+
+// tonelliShanks finds a square root of the quadratic residue a mod p, where p-1 = q*2^s with q odd.
+func tonelliShanks(a, p, s int) int {
+	q := (p - 1) >> s
+	z := 2
+	for Legendre(z, p) != -1 {
+		z++
+	}
+
+	m, c, t, r := s, PowMod(z, q, p), PowMod(a, q, p), PowMod(a, (q+1)/2, p)
+	for t != 1 {
+		// Find the smallest i such that t^(2^i) = 1
+		i, sqr := 0, t
+		for sqr != 1 {
+			sqr = MulMod(sqr, sqr, p)
+			i++
+		}
+		b := PowMod(c, 1<<(m-i-1), p)
+		m, c = i, MulMod(b, b, p)
+		t, r = MulMod(t, c, p), MulMod(r, b, p)
+	}
+	return r
+}
+
+// cipolla finds a square root of the quadratic residue a mod p by working in the field extension
+// F_p(w) where w^2 = t^2-a is not a quadratic residue. In that field (t+w)^((p+1)/2) is a root.
+func cipolla(a, p int) int {
+	t := 1
+	for Legendre(addMod(MulMod(t, t, p), p-a, p), p) != -1 {
+		t++
+	}
+	w2 := addMod(MulMod(t, t, p), p-a, p)
+
+	// (x1 + y1*w) * (x2 + y2*w) = (x1*x2 + y1*y2*w^2) + (x1*y2 + x2*y1)*w
+	mul := func(x1, y1, x2, y2 int) (int, int) {
+		x := addMod(MulMod(x1, x2, p), MulMod(MulMod(y1, y2, p), w2, p), p)
+		y := addMod(MulMod(x1, y2, p), MulMod(x2, y1, p), p)
+		return x, y
+	}
+	rx, ry, bx, by := 1, 0, t, 1
+	for e := (p + 1) / 2; e > 0; e >>= 1 {
+		if e&1 != 0 {
+			rx, ry = mul(rx, ry, bx, by)
+		}
+		bx, by = mul(bx, by, bx, by)
+	}
+	return rx
+}
+
+// SqrtModPrimePower returns all x in [0, p^k) in ascending order such that x^2 ≡ a (mod p^k) for a
+// prime p. Roots mod p are lifted to p^k using Hensel's lemma, with the special cases needed for
+// p = 2 and for a divisible by p. It returns an error wrapping ErrNoSolution if there are no roots,
+// ErrNotPrime if p isn't prime, or ErrOverflow if p^k doesn't fit in an int.
+func SqrtModPrimePower(a, p, k int) ([]int, error) {
+	if !IsPrime(p) {
+		return nil, fmt.Errorf("cannot use modulus %d: %w", p, ErrNotPrime)
+	}
+	if k < 1 {
+		return nil, fmt.Errorf("cannot use exponent %d: %w", k, ErrNotNatural)
+	}
+	// Every other power of p used below has a smaller exponent, so they all fit if this one does.
+	mod, ok := checkedPow(p, k)
+	if !ok {
+		return nil, fmt.Errorf("modulus %d^%d doesn't fit in an int: %w", p, k, ErrOverflow)
+	}
+	a = int(reduceMod(a, mod))
+
+	// Split a into p^v * b with b coprime with p. Any root x must then be p^(v/2) * y, where y is a
+	// root of b mod p^(k-v).
+	v, b := 0, a
+	for b != 0 && b%p == 0 {
+		v, b = v+1, b/p
+	}
+	if b == 0 {
+		// Every multiple of p^ceil(k/2) squares to 0.
+		step := Pow(p, (k+1)/2)
+		var result []int
+		for x := 0; x < mod; x += step {
+			result = append(result, x)
+		}
+		return result, nil
+	}
+	if v%2 != 0 {
+		return nil, fmt.Errorf("%d is not a quadratic residue mod %d: %w", a, mod, ErrNoSolution)
+	}
+
+	roots, err := sqrtCoprimePower(b, p, k-v)
+	if err != nil {
+		return nil, fmt.Errorf("%d is not a quadratic residue mod %d: %w", a, mod, ErrNoSolution)
+	}
+	if v == 0 {
+		return roots, nil
+	}
+
+	// y only matters mod p^(k-v/2), but is only determined mod p^(k-v), so every one of the
+	// p^(v/2) choices for the rest of y gives a different root.
+	scale, step := Pow(p, v/2), Pow(p, k-v)
+	var result []int
+	for _, y := range roots {
+		for ; y < mod/scale; y += step {
+			result = append(result, y*scale)
+		}
+	}
+	slices.Sort(result)
+	return result, nil
+}
+
+// sqrtCoprimePower returns all the roots of a mod p^k in ascending order, where a is coprime with p.
+func sqrtCoprimePower(a, p, k int) ([]int, error) {
+	mod := Pow(p, k)
+	if p != 2 {
+		root, err := SqrtModPrime(a, p)
+		if err != nil {
+			return nil, err
+		}
+		// Hensel's lemma: if r^2 ≡ a (mod p^i) then r - (r^2-a)/(2r) is a root mod p^(i+1).
+		for i, pi := 1, p; i < k; i++ {
+			pi *= p
+			inv, _ := ModInverse(addMod(root, root, pi), pi)
+			diff := addMod(MulMod(root, root, pi), pi-a%pi, pi)
+			root = addMod(root, pi-MulMod(diff, inv, pi), pi)
+		}
+		return []int{min(root, mod-root), max(root, mod-root)}, nil
+	}
+
+	switch {
+	case k == 1:
+		return []int{1}, nil
+	case k == 2:
+		if a%4 != 1 {
+			return nil, ErrNoSolution
+		}
+		return []int{1, 3}, nil
+	case a%8 != 1:
+		return nil, ErrNoSolution
+	}
+	// If r is a root mod 2^i then either r or r+2^(i-1) is a root mod 2^(i+1).
+	root := 1
+	for i := 3; i < k; i++ {
+		next := 1 << (i + 1)
+		if MulMod(root, root, next) != a%next {
+			root += 1 << (i - 1)
+		}
+	}
+	half := mod / 2
+	result := []int{root, mod - root, (root + half) % mod, (mod - root + half) % mod}
+	slices.Sort(result)
+	return result, nil
+}
+
+// SqrtMod returns all x in [0, n) in ascending order such that x^2 ≡ a (mod n). The roots for
+// each prime power dividing n are found separately and then every combination of them is joined
+// together with the Chinese Remainder Theorem. It returns an error wrapping ErrNoSolution if there
+// are no roots.
+func SqrtMod(a, n int) ([]int, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("cannot use modulus %d: %w", n, ErrNotNatural)
+	}
+	result := []int{0}
+	mod := 1
+	for prime, cnt := range FactorMap(n) {
+		roots, err := SqrtModPrimePower(a, prime, cnt)
+		if err != nil {
+			return nil, fmt.Errorf("%d is not a quadratic residue mod %d: %w", a, n, ErrNoSolution)
+		}
+		pk := Pow(prime, cnt)
+		combined := make([]int, 0, len(result)*len(roots))
+		for _, x := range result {
+			for _, y := range roots {
+				r, _, err := CRT(Congruence{x, mod}, Congruence{y, pk})
+				if err != nil {
+					return nil, err
+				}
+				combined = append(combined, r)
+			}
+		}
+		result, mod = combined, mod*pk
+	}
+	slices.Sort(result)
+	return result, nil
+}
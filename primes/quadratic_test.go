@@ -0,0 +1,102 @@
+package primes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJacobi(t *testing.T) {
+	type s struct {
+		a, n, r int
+	}
+	expected := []s{
+		{1, 1, 1},
+		{2, 7, 1},
+		{3, 7, -1},
+		{14, 7, 0},
+		{1001, 9907, -1},
+		{19, 45, 1},
+		{8, 21, -1},
+		{-1, 13, 1},
+	}
+
+	for _, e := range expected {
+		if r := Jacobi(e.a, e.n); r != e.r {
+			t.Errorf("Jacobi(%d, %d) returned %d, expected %d", e.a, e.n, r, e.r)
+		}
+	}
+}
+
+func TestSqrtModPrime(t *testing.T) {
+	// These cover p = 3 (mod 4), Tonelli-Shanks, and Cipolla with p-1 divisible by 2^23.
+	for _, p := range []int{1e9 + 7, 1e9 + 9, 998244353, 1<<61 - 1, 2} {
+		for _, a := range []int{0, 1, 4, 5, 123456789, p - 1} {
+			root, err := SqrtModPrime(a, p)
+			if p != 2 && Legendre(a, p) == -1 {
+				if !errors.Is(err, ErrNoSolution) {
+					t.Errorf("SqrtModPrime(%d, %d) returned error %v, expected ErrNoSolution", a, p, err)
+				}
+			} else if err != nil || MulMod(root, root, p) != a%p || root > p-root {
+				t.Errorf("SqrtModPrime(%d, %d) returned %d, %v", a, p, root, err)
+			}
+		}
+	}
+}
+
+func TestSqrtModComposite(t *testing.T) {
+	type s struct {
+		a, p int
+	}
+	for _, e := range []s{{2, 15}, {1, 9}, {4, 1}, {0, 0}, {3, -7}, {1, 561}, {4, 3215031751}} {
+		if root, err := SqrtModPrime(e.a, e.p); !errors.Is(err, ErrNotPrime) {
+			t.Errorf("SqrtModPrime(%d, %d) returned %d, %v, expected ErrNotPrime", e.a, e.p, root, err)
+		}
+		if roots, err := SqrtModPrimePower(e.a, e.p, 2); !errors.Is(err, ErrNotPrime) {
+			t.Errorf("SqrtModPrimePower(%d, %d, 2) returned %d, %v, expected ErrNotPrime", e.a, e.p, roots, err)
+		}
+	}
+}
+
+func TestSqrtModPrimePowerOverflow(t *testing.T) {
+	type s struct {
+		a, p, k int
+	}
+	for _, e := range []s{{4, 3, 50}, {17, 2, 64}, {0, 2, 63}, {1, 1e9 + 7, 3}} {
+		if roots, err := SqrtModPrimePower(e.a, e.p, e.k); !errors.Is(err, ErrOverflow) {
+			t.Errorf("SqrtModPrimePower(%d, %d, %d) returned %d, %v, expected ErrOverflow", e.a, e.p, e.k, roots, err)
+		}
+	}
+	if r, err := SqrtModPrimePower(4, 3, 39); err != nil || len(r) != 2 || r[0] != 2 {
+		t.Errorf("SqrtModPrimePower(4, 3, 39) returned %d, %v, expected 2 roots starting with 2", r, err)
+	}
+}
+
+func TestSqrtMod(t *testing.T) {
+	for n := 1; n <= 300; n++ {
+		for a := 0; a < n; a++ {
+			var expected []int
+			for x := 0; x < n; x++ {
+				if x*x%n == a {
+					expected = append(expected, x)
+				}
+			}
+
+			roots, err := SqrtMod(a, n)
+			if expected == nil {
+				if !errors.Is(err, ErrNoSolution) {
+					t.Errorf("SqrtMod(%d, %d) returned %d, %v, expected ErrNoSolution", a, n, roots, err)
+				}
+			} else if err != nil || !reflect.DeepEqual(roots, expected) {
+				t.Errorf("SqrtMod(%d, %d) returned %d, %v, expected %d", a, n, roots, err, expected)
+			}
+		}
+	}
+
+	if r, err := SqrtModPrimePower(17, 2, 40); err != nil || len(r) != 4 || MulMod(r[0], r[0], 1<<40) != 17 {
+		t.Errorf("SqrtModPrimePower(17, 2, 40) returned %d, %v", r, err)
+	}
+	if r, err := SqrtModPrimePower(2, 7, 20); err != nil || len(r) != 2 || MulMod(r[1], r[1], Pow(7, 20)) != 2 {
+		t.Errorf("SqrtModPrimePower(2, 7, 20) returned %d, %v", r, err)
+	}
+}
@@ -0,0 +1,82 @@
+package primes
+
+// trialLimit is the largest prime Factor will try dividing by before it switches over to using
+// Pollard's rho algorithm to split whatever is left.
+const trialLimit = 1 << 16
+
+func gcd64(a, b uint64) uint64 {
+	for b > 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// brent uses Brent's variant of Pollard's rho algorithm with the polynomial x^2+c to search for
+// a non-trivial factor of the odd number num. It can fail, in which case it returns num itself.
+// All of the arithmetic is done in Montgomery form, which doesn't change the GCDs since it only
+// multiplies everything by a unit.
+func brent(num, c uint64) uint64 {
+	const batch = 128
+	mont := NewMontgomery(num)
+	step := func(x uint64) uint64 {
+		x = mont.Mul(x, x) + c
+		if x >= num || x < c {
+			x -= num
+		}
+		return x
+	}
+	diff := func(a, b uint64) uint64 {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+
+	x, y, ys, q, g := uint64(0), uint64(2), uint64(0), uint64(1), uint64(1)
+	for r := 1; g == 1; r *= 2 {
+		x = y
+		for i := 0; i < r; i++ {
+			y = step(y)
+		}
+		// Multiply a batch of differences together before taking the GCD, since the GCD is much
+		// more expensive than the modular multiplication.
+		for k := 0; k < r && g == 1; k += batch {
+			ys = y
+			for i := 0; i < batch && i < r-k; i++ {
+				y = step(y)
+				q = mont.Mul(q, diff(x, y))
+			}
+			g = gcd64(q, num)
+		}
+	}
+
+	// If the batch overshot and multiplied in all of the factors at once backtrack one step at a
+	// time from the start of the batch.
+	if g == num {
+		for g = 1; g == 1; {
+			ys = step(ys)
+			g = gcd64(diff(x, ys), num)
+		}
+	}
+	return g
+}
+
+// appendRhoFactors appends all of the prime factors of num to the list in no particular order.
+func appendRhoFactors(list []uint64, num uint64) []uint64 {
+	if num == 1 {
+		return list
+	}
+	if isPrime64(num) {
+		return append(list, num)
+	}
+	if num%2 == 0 {
+		return appendRhoFactors(append(list, 2), num/2)
+	}
+
+	div := num
+	for c := uint64(1); div == num; c++ {
+		div = brent(num, c)
+	}
+	list = appendRhoFactors(list, div)
+	return appendRhoFactors(list, num/div)
+}
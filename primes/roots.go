@@ -0,0 +1,118 @@
+package primes
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// isqrt64 returns the largest integer whose square is no bigger than x using Newton's method. The
+// starting guess is a power of 2 that's at least as big as the root, and from there every
+// iteration gets smaller until it reaches the floor of the root.
+func isqrt64(x uint64) uint64 {
+	if x < 2 {
+		return x
+	}
+	r := uint64(1) << ((bits.Len64(x) + 1) / 2)
+	for {
+		next := (r + x/r) / 2
+		if next >= r {
+			return r
+		}
+		r = next
+	}
+}
+
+// powSaturate returns x^k, or false if it doesn't fit in 64 bits.
+func powSaturate(x uint64, k int) (uint64, bool) {
+	p := uint64(1)
+	for i := 0; i < k; i++ {
+		hi, lo := bits.Mul64(p, x)
+		if hi != 0 {
+			return 0, false
+		}
+		p = lo
+	}
+	return p, true
+}
+
+// iroot64 returns the largest integer whose kth power is no bigger than x using Newton's method.
+func iroot64(x uint64, k int) uint64 {
+	switch {
+	case k == 1 || x < 2:
+		return x
+	case k == 2:
+		return isqrt64(x)
+	case k >= bits.Len64(x):
+		return 1
+	}
+
+	r := uint64(1) << ((bits.Len64(x) + k - 1) / k)
+	for {
+		// If r^(k-1) doesn't fit then x/r^(k-1) is 0.
+		var quo uint64
+		if p, ok := powSaturate(r, k-1); ok {
+			quo = x / p
+		}
+		next := (uint64(k-1)*r + quo) / uint64(k)
+		if next >= r {
+			return r
+		}
+		r = next
+	}
+}
+
+// ISqrt returns the largest integer whose square is no bigger than n. Unlike using math.Sqrt it's
+// exact for every int, including those beyond the 2^53 where float64 loses precision.
+func ISqrt(n int) int {
+	if n < 0 {
+		panic(fmt.Errorf("cannot take the square root of negative number %d", n))
+	}
+	return int(isqrt64(uint64(n)))
+}
+
+// ICbrt returns the integer cube root of n, rounded towards zero.
+func ICbrt(n int) int {
+	return IRoot(n, 3)
+}
+
+// IRoot returns the integer kth root of n, rounded towards zero. Negative numbers only have roots
+// when k is odd.
+func IRoot(n, k int) int {
+	if k < 1 {
+		panic(fmt.Errorf("cannot take root %d", k))
+	}
+	if n < 0 {
+		if k%2 == 0 {
+			panic(fmt.Errorf("cannot take even root %d of negative number %d", k, n))
+		}
+		return -int(iroot64(-uint64(n), k))
+	}
+	return int(iroot64(uint64(n), k))
+}
+
+// IsPerfectPower checks if n can be written as base^exp for some exp >= 2. If it can the smallest
+// possible base (and so the largest exponent) is returned. Negative numbers are only considered
+// perfect powers with odd exponents.
+func IsPerfectPower(n int) (base, exp int, ok bool) {
+	abs := uint64(n)
+	if n < 0 {
+		abs = -abs
+	}
+	if abs < 2 {
+		return 0, 0, false
+	}
+
+	for k := bits.Len64(abs) - 1; k >= 2; k-- {
+		if n < 0 && k%2 == 0 {
+			continue
+		}
+		r := iroot64(abs, k)
+		if p, _ := powSaturate(r, k); p == abs {
+			if n < 0 {
+				return -int(r), k, true
+			}
+			return int(r), k, true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,81 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestISqrt(t *testing.T) {
+	type s struct {
+		n, r int
+	}
+	expected := []s{
+		{0, 0},
+		{1, 1},
+		{15, 3},
+		{16, 4},
+		{1e17 - 1, 316227766},
+		{99999999999999999, 316227766},
+		{(1<<31 - 1) * (1<<31 - 1), 1<<31 - 1},
+		{math.MaxInt, 3037000499},
+	}
+
+	for _, e := range expected {
+		if r := ISqrt(e.n); r != e.r {
+			t.Errorf("ISqrt(%d) returned %d, expected %d", e.n, r, e.r)
+		}
+	}
+	if !IsSquare(316227766*316227766) || IsSquare(316227766*316227766+1) {
+		t.Errorf("IsSquare gave the wrong answer around 316227766^2")
+	}
+}
+
+func TestIRoot(t *testing.T) {
+	type s struct {
+		n, k, r int
+	}
+	expected := []s{
+		{27, 3, 3},
+		{26, 3, 2},
+		{-27, 3, -3},
+		{math.MaxInt, 3, 2097151},
+		{1e18, 3, 1e6},
+		{1e18 - 1, 3, 999999},
+		{1e18, 6, 1000},
+		{math.MaxInt, 62, 2},
+		{math.MaxInt, 63, 1},
+		{7, 1, 7},
+	}
+
+	for _, e := range expected {
+		if r := IRoot(e.n, e.k); r != e.r {
+			t.Errorf("IRoot(%d, %d) returned %d, expected %d", e.n, e.k, r, e.r)
+		}
+	}
+	if r := ICbrt(1 << 60); r != 1<<20 {
+		t.Errorf("ICbrt(2^60) returned %d, expected %d", r, 1<<20)
+	}
+}
+
+func TestIsPerfectPower(t *testing.T) {
+	type s struct {
+		n, base, exp int
+		ok           bool
+	}
+	expected := []s{
+		{1, 0, 0, false},
+		{12, 0, 0, false},
+		{64, 2, 6, true},
+		{-32, -2, 5, true},
+		{-64, -4, 3, true},
+		{3486784401, 3, 20, true},
+		{999999999999999999, 0, 0, false},
+		{1 << 62, 2, 62, true},
+	}
+
+	for _, e := range expected {
+		if base, exp, ok := IsPerfectPower(e.n); base != e.base || exp != e.exp || ok != e.ok {
+			t.Errorf("IsPerfectPower(%d) returned %d, %d, %t, expected %d, %d, %t", e.n, base, exp, ok, e.base, e.exp, e.ok)
+		}
+	}
+}
@@ -1,127 +1,267 @@
 package primes
 
 import (
+	"fmt"
 	"math"
+	"math/bits"
 	"sort"
+	"sync"
 )
 
+// Sieve keeps track of every prime it has found so far, expanding its cache as larger numbers are
+// requested. It is safe for concurrent use by multiple goroutines, and the zero value is ready to
+// use. Most callers should just use the package level functions, which share a default Sieve.
+type Sieve struct {
+	mu     sync.RWMutex
+	primes []int
+	limit  int
+}
+
 var (
-	cachedSieve = []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
-	cachedRange = int(30)
+	defaultSieve = new(Sieve)
+
+	// Every sieve starts out knowing the primes up to smallLimit.
+	smallPrimes = []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	smallLimit  = int(30)
 )
 
-// resetSieve is for testing purposes only.
-func resetSieve() {
-	cachedSieve = []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
-	cachedRange = int(30)
+// NewSieve creates a new Sieve with its own independent cache.
+func NewSieve() *Sieve {
+	return new(Sieve)
 }
 
-func expandSieve(num int) {
-	if num < cachedRange {
-		return
+// The sieve is extended one segment at a time, where each segment is an odd-only bitset. Bit i
+// of a segment starting at lo represents the number lo+2*i, so a segment covers a span of twice
+// as many numbers as it has bits. The segment size is kept small enough to fit in the L1/L2 cache
+// regardless of how far the sieve needs to be expanded.
+const (
+	segmentWords = 1 << 12
+	segmentBits  = 64 * segmentWords
+	segmentSpan  = 2 * segmentBits
+)
+
+// sieveSegment clears bits and then marks every odd composite number in the segment starting at
+// the odd number lo. The primes list must contain every prime up to the square root of the largest
+// number the segment represents.
+func sieveSegment(segment []uint64, lo int, primes []int) {
+	for i := range segment {
+		segment[i] = 0
 	}
-	const minDiff, maxDiff = int(200), int(1 << 23)
-	for num-cachedRange > maxDiff {
-		expandSieve(cachedRange + maxDiff)
+	hi := lo + 2*(64*len(segment)-1)
+	if hi < lo {
+		hi = math.MaxInt
 	}
-	if num-cachedRange < minDiff {
-		num = cachedRange + minDiff
+	for _, prime := range primes {
+		if prime == 2 {
+			continue
+		}
+		if prime*prime > hi {
+			break
+		}
+		// Start at either the square of the prime or the first odd multiple inside the segment,
+		// whichever is bigger. Anything smaller will have been marked by a smaller prime.
+		start := prime * prime
+		if start < lo {
+			start = ((lo-1)/prime + 1) * prime
+			if start%2 == 0 {
+				start += prime
+			}
+		}
+		for ind := (start - lo) / 2; ind < 64*len(segment); ind += prime {
+			segment[ind>>6] |= 1 << (ind & 63)
+		}
 	}
+}
 
-	// Establish all of the prime candidates in the range we haven't checked yet
-	var start int
-	if cachedRange%2 == 0 {
-		start = cachedRange + 1
-	} else {
-		start = cachedRange
+// collectSegment appends every number in the segment starting at lo that wasn't marked by
+// sieveSegment and is no bigger than limit.
+func collectSegment(list []int, segment []uint64, lo, limit int) []int {
+	for i, word := range segment {
+		for word = ^word; word != 0; word &= word - 1 {
+			num := lo + 2*(64*i+bits.TrailingZeros64(word))
+			if num > limit || num < lo {
+				return list
+			}
+			list = append(list, num)
+		}
 	}
-	candidates := make(map[int]bool, (num-cachedRange)/2+1)
-	for ind := start; ind <= num; ind += 2 {
-		candidates[ind] = true
+	return list
+}
+
+// window returns all of the primes in the range [lo, hi] without needing to expand the sieve
+// beyond the square root of hi. The range is sieved one segment at a time.
+func (s *Sieve) window(lo, hi int) []int {
+	var result []int
+	if lo <= 2 && hi >= 2 {
+		result = append(result, 2)
+	}
+	if lo < 3 {
+		lo = 3
+	} else if lo%2 == 0 {
+		lo++
+	}
+	if hi < lo {
+		return result
 	}
 
-	// First go through our existing primes and remove all multiples of those
-	for _, prime := range cachedSieve {
-		for ind := ((cachedRange-1)/prime + 1) * prime; ind <= num; ind += prime {
-			candidates[ind] = false
+	base := s.expand(ISqrt(hi))
+	segment := make([]uint64, segmentWords)
+	for ; lo <= hi; lo += segmentSpan {
+		top := lo + segmentSpan - 2
+		if top > hi || top < lo {
+			top = hi
 		}
+		words := ((top-lo)/2)/64 + 1
+		sieveSegment(segment[:words], lo, base)
+		result = collectSegment(result, segment[:words], lo, top)
+		if top == hi {
+			break
+		}
+	}
+	return result
+}
+
+// cached returns all of the primes the sieve has already found along with the limit it has
+// sieved up to. The slice is only ever appended to, so it's safe to read after releasing the lock.
+func (s *Sieve) cached() ([]int, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.limit == 0 {
+		return smallPrimes, smallLimit
 	}
+	return s.primes, s.limit
+}
 
-	// Then go through all new number in ascending order and store everything that's still a candidate
-	for ind := start; ind <= num; ind += 2 {
-		if !candidates[ind] {
-			continue
+// expand makes sure the sieve contains every prime up to num and returns the cached primes.
+func (s *Sieve) expand(num int) []int {
+	if list, limit := s.cached(); num <= limit {
+		return list
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit == 0 {
+		s.primes, s.limit = append([]int(nil), smallPrimes...), smallLimit
+	}
+	if num <= s.limit {
+		return s.primes
+	}
+	const minDiff = int(200)
+	if num-s.limit < minDiff {
+		num = s.limit + minDiff
+	}
+
+	segment := make([]uint64, segmentWords)
+	for s.limit < num {
+		lo := s.limit + 1
+		if lo%2 == 0 {
+			lo++
+		}
+		// We can only trust the results of the segment up to the square of the largest prime we
+		// have, so the first few segments might need to be smaller than the others.
+		hi := lo + segmentSpan - 2
+		if hi > num {
+			hi = num
+		}
+		if s.limit <= math.MaxInt/s.limit && hi > s.limit*s.limit {
+			hi = s.limit * s.limit
 		}
 
-		cachedSieve = append(cachedSieve, ind)
-		for j := 2 * ind; j <= num; j += ind {
-			candidates[j] = false
+		if hi >= lo {
+			words := ((hi-lo)/2)/64 + 1
+			sieveSegment(segment[:words], lo, s.primes)
+			s.primes = collectSegment(s.primes, segment[:words], lo, hi)
 		}
+		s.limit = hi
 	}
-	cachedRange = num
+	return s.primes
 }
 
 // IsPrime checks to see if the specified number is prime.
-func IsPrime(num int) bool {
-	if cachedRange >= num {
-		l := len(cachedSieve)
-		ind := sort.Search(l, func(i int) bool { return cachedSieve[i] >= num })
-		return ind < l && cachedSieve[ind] == num
-	}
-
-	limit := int(math.Sqrt(float64(num)))
-	expandSieve(limit)
-	for _, prime := range cachedSieve {
-		if num%prime == 0 {
-			return false
-		}
-		if prime > limit {
-			break
-		}
+func IsPrime(num int) bool { return defaultSieve.IsPrime(num) }
+
+// IsPrime checks to see if the specified number is prime. Numbers beyond what the sieve has
+// already cached are checked with a deterministic Miller-Rabin test instead of expanding the sieve.
+func (s *Sieve) IsPrime(num int) bool {
+	if list, limit := s.cached(); num <= limit {
+		ind := sort.SearchInts(list, num)
+		return ind < len(list) && list[ind] == num
 	}
-	return true
+	return isPrime64(uint64(num))
 }
 
 // PrimeIndex takes a number, and if it is prime it will return its index. It is
 // the inverse of NthPrime
-func PrimeIndex(num int) int {
-	if num < cachedRange {
-		expandSieve(num)
-	}
-	l := len(cachedSieve)
-	ind := sort.Search(l, func(i int) bool { return cachedSieve[i] >= num })
-	if ind >= l || cachedSieve[ind] != num {
+func PrimeIndex(num int) int { return defaultSieve.PrimeIndex(num) }
+
+// PrimeIndex takes a number, and if it is prime it will return its index. It is
+// the inverse of NthPrime
+func (s *Sieve) PrimeIndex(num int) int {
+	if !s.IsPrime(num) {
 		return -1
 	}
-	// account for 0-indexing of the array
-	return ind + 1
+	return s.PrimeCount(num)
 }
 
 // NthPrime returns the value of the nth prime (starting with 2 as the 1st prime).
-func NthPrime(num int) int {
-	// acount for 0-indexing of the array
-	num--
-	for rng := cachedRange + 1e3; num >= len(cachedSieve); rng += 1e3 {
-		expandSieve(rng)
+func NthPrime(num int) int { return defaultSieve.NthPrime(num) }
+
+// NthPrime returns the value of the nth prime (starting with 2 as the 1st prime). If the answer is
+// too big to reasonably sieve it uses PrimeCount around an estimate and then steps to the nth prime
+// by sieving the small window between the estimate and the actual value.
+func (s *Sieve) NthPrime(num int) int {
+	if num < 1 {
+		panic(fmt.Errorf("there is no prime with index %d", num))
+	}
+	// account for 0-indexing of the array
+	if list, _ := s.cached(); num <= len(list) {
+		return list[num-1]
+	}
+	if bound := nthPrimeBound(num); bound <= nthPrimeSieveLimit {
+		return s.expand(bound)[num-1]
+	}
+
+	est := nthPrimeEstimate(num)
+	if cnt := s.PrimeCount(est); cnt >= num {
+		// Step backwards through the primes <= est until we've skipped the extra ones.
+		skip := cnt - num
+		for prime := range s.DownFrom(est) {
+			if skip == 0 {
+				return prime
+			}
+			skip--
+		}
+	} else {
+		need := num - cnt
+		for prime := range s.From(est + 1) {
+			if need--; need == 0 {
+				return prime
+			}
+		}
 	}
-	return cachedSieve[num]
+	panic(fmt.Errorf("ran out of primes looking for prime %d", num))
 }
 
 // Between returns a list of all primes in the specified range
-func Between(lower, upper int) []int {
+func Between(lower, upper int) []int { return defaultSieve.Between(lower, upper) }
+
+// Between returns a list of all primes in the specified range
+func (s *Sieve) Between(lower, upper int) []int {
 	if lower > upper {
 		return nil
 	}
-	expandSieve(upper)
-	ind1 := sort.Search(len(cachedSieve), func(i int) bool { return cachedSieve[i] >= lower })
-	if ind1 < 0 {
-		ind1 = 0
-	}
-	ind2 := sort.Search(len(cachedSieve), func(i int) bool { return cachedSieve[i] >= upper })
-	if ind2 > len(cachedSieve) {
-		ind2 = len(cachedSieve)
-	} else if ind2 < len(cachedSieve)-1 && cachedSieve[ind2] == upper {
-		ind2++
+	list := s.expand(upper)
+	ind1 := sort.SearchInts(list, lower)
+	ind2 := countUpTo(list, upper)
+	return append([]int(nil), list[ind1:ind2]...)
+}
+
+// countUpTo returns how many numbers in the sorted list are no bigger than num. It searches for
+// num itself and steps past an exact match, since searching for num+1 would overflow for MaxInt.
+func countUpTo(list []int, num int) int {
+	ind := sort.SearchInts(list, num)
+	if ind < len(list) && list[ind] == num {
+		ind++
 	}
-	return append([]int(nil), cachedSieve[ind1:ind2]...)
+	return ind
 }
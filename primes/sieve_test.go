@@ -2,6 +2,7 @@ package primes
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -22,16 +23,58 @@ func TestBetween(t *testing.T) {
 }
 
 func TestExpand(t *testing.T) {
-	resetSieve()
-	if list := Between(2, 255); list[len(list)-1] != 251 {
-		t.Errorf("expected primes from 2-255 to end in 7069; got %d", list[len(list)-1])
+	s := NewSieve()
+	if list := s.Between(2, 255); list[len(list)-1] != 251 {
+		t.Errorf("expected primes from 2-255 to end in 251; got %d", list[len(list)-1])
 	}
-	if list := Between(2, 255); list[len(list)-1] != 251 {
+	if list := s.Between(2, 255); list[len(list)-1] != 251 {
 		t.Errorf("expected primes from 2-255 to end in 251; got %d", list[len(list)-1])
 	}
 
-	// Not interested in the results so much as making sure it doesn't crash
 	if !testing.Short() {
-		Between(1, 1<<26)
+		if cnt := len(s.Between(1, 1<<26)); cnt != 3957809 {
+			t.Errorf("expected 3957809 primes below 2^26; got %d", cnt)
+		}
+	}
+}
+
+func TestExpandLarge(t *testing.T) {
+	// Pretend the sieve has already gone past the point where the square of its limit overflows.
+	// The primes up to 2^16 are all that's needed to sieve everything below 2^32.
+	const limit = 3037000500
+	s := &Sieve{primes: Between(2, 1<<16), limit: limit}
+	list := s.Between(limit, limit+1e5)
+	var expected []int
+	for num := limit; num <= limit+1e5; num++ {
+		if isPrime64(uint64(num)) {
+			expected = append(expected, num)
+		}
+	}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("expected %d primes from %d-%d; got %d", len(expected), limit, limit+int(1e5), len(list))
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	s := NewSieve()
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(upper int) {
+			defer wg.Done()
+			if !s.IsPrime(7919) {
+				t.Errorf("expected 7919 to be prime")
+			}
+			if list := s.Between(2, upper); len(list) == 0 || list[len(list)-1] > upper {
+				t.Errorf("invalid primes returned for 2-%d", upper)
+			}
+			if p := s.NthPrime(1000); p != 7919 {
+				t.Errorf("expected 1000th prime to be 7919; got %d", p)
+			}
+			if f := s.Factor(upper * 97); f[len(f)-1] != 97 {
+				t.Errorf("expected largest factor of %d to be 97; got %d", upper*97, f)
+			}
+		}(i * 1e4)
 	}
+	wg.Wait()
 }
@@ -0,0 +1,252 @@
+package primes
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// SigmaK returns the sum of the kth powers of the divisors of n, panicking if it overflows.
+// SigmaK(n, 1) is the sum of the divisors, and SigmaK(n, 0) is the number of divisors.
+func SigmaK(n, k int) int {
+	result, err := TrySigmaK(n, k)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TrySigmaK is like SigmaK, but returns an error wrapping ErrOverflow if the result doesn't fit
+// in an int. It's calculated from the prime factorization, using the fact that the sum for p^e is
+// 1 + p^k + p^2k + ... + p^ek and that the function is multiplicative.
+func TrySigmaK(n, k int) (int, error) {
+	if k < 0 {
+		return 0, fmt.Errorf("cannot use negative power %d: %w", k, ErrNotNatural)
+	}
+	factors, err := TryFactorMap(n)
+	if err != nil {
+		return 0, err
+	}
+
+	result := 1
+	for prime, cnt := range factors {
+		sum, ok := sigmaPrimePower(prime, cnt, k)
+		if ok {
+			result, ok = checkedMul(result, sum)
+		}
+		if !ok {
+			return 0, fmt.Errorf("sigma_%d(%d) doesn't fit in an int: %w", k, n, ErrOverflow)
+		}
+	}
+	return result, nil
+}
+
+// sigmaPrimePower returns 1 + p^k + p^2k + ... + p^ek, and whether the result fit in an int.
+func sigmaPrimePower(prime, exp, k int) (int, bool) {
+	step, ok := checkedPow(prime, k)
+	if !ok {
+		return 0, false
+	}
+	sum, term := 1, 1
+	for i := 0; i < exp; i++ {
+		if term, ok = checkedMul(term, step); !ok || sum > math.MaxInt-term {
+			return 0, false
+		}
+		sum += term
+	}
+	return sum, true
+}
+
+// SigmaRange is like TrySigmaRange, but panics if any of the sums overflow.
+func SigmaRange(lo, hi, k int) []int {
+	result, err := TrySigmaRange(lo, hi, k)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TrySigmaRange returns the sum of the kth powers of the divisors of every number in [lo, hi],
+// with the result for n at index n-lo. Rather than factoring each number separately it sieves the
+// range with every prime up to sqrt(hi), so it doesn't need to build a table starting from 1 the
+// way SigmaTable does. It returns an error wrapping ErrOverflow if any of the sums don't fit in
+// an int.
+func TrySigmaRange(lo, hi, k int) ([]int, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("cannot use negative power %d: %w", k, ErrNotNatural)
+	}
+	if lo < 1 {
+		lo = 1
+	}
+	if hi < lo {
+		return nil, nil
+	}
+	result := make([]int, hi-lo+1)
+	rest := make([]int, hi-lo+1)
+	for i := range result {
+		result[i], rest[i] = 1, lo+i
+	}
+
+	overflow := func(i int) error {
+		return fmt.Errorf("sigma_%d(%d) doesn't fit in an int: %w", k, lo+i, ErrOverflow)
+	}
+	for _, prime := range Between(2, ISqrt(hi)) {
+		for mult := (lo + prime - 1) / prime * prime; mult <= hi; mult += prime {
+			i, exp := mult-lo, 0
+			for rest[i]%prime == 0 {
+				rest[i] /= prime
+				exp++
+			}
+			sum, ok := sigmaPrimePower(prime, exp, k)
+			if ok {
+				result[i], ok = checkedMul(result[i], sum)
+			}
+			if !ok {
+				return nil, overflow(i)
+			}
+		}
+	}
+	// Anything left over has to be a single prime bigger than sqrt(hi).
+	for i, prime := range rest {
+		if prime > 1 {
+			sum, ok := sigmaPrimePower(prime, 1, k)
+			if ok {
+				result[i], ok = checkedMul(result[i], sum)
+			}
+			if !ok {
+				return nil, overflow(i)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Abundance classifies a number by comparing the sum of its proper divisors to the number itself.
+type Abundance int
+
+// The possible classifications of a number.
+const (
+	Deficient Abundance = iota - 1
+	Perfect
+	Abundant
+)
+
+func (a Abundance) String() string {
+	switch a {
+	case Deficient:
+		return "deficient"
+	case Perfect:
+		return "perfect"
+	case Abundant:
+		return "abundant"
+	}
+	return fmt.Sprintf("Abundance(%d)", int(a))
+}
+
+// Classify returns whether the sum of the proper divisors of n is less than, equal to, or greater
+// than n itself.
+func Classify(n int) Abundance {
+	switch sum := AliquotSum(n); {
+	case sum < n:
+		return Deficient
+	case sum > n:
+		return Abundant
+	}
+	return Perfect
+}
+
+// AliquotSum returns the sum of the proper divisors of n (every divisor except n itself).
+func AliquotSum(n int) int {
+	return SigmaK(n, 1) - n
+}
+
+// AliquotSequence is the result of repeatedly replacing a number with the sum of its proper
+// divisors.
+type AliquotSequence struct {
+	// Terms contains every distinct term of the sequence in order, starting with the initial
+	// number. If the sequence terminates the last term will be 1.
+	Terms []int
+	// CycleStart is the index in Terms where the sequence starts repeating, or -1 if it didn't.
+	// A cycle of length 1 is a perfect number, 2 is an amicable pair, and longer ones are sociable.
+	CycleStart int
+	// Escaped is set if a term exceeded the limit before the sequence terminated or cycled.
+	Escaped bool
+}
+
+// Aliquot follows the aliquot sequence starting with n until it either terminates, enters a cycle,
+// or a term exceeds limit. Since every term up to the limit is finite the sequence will always
+// end one way or another.
+func Aliquot(n, limit int) AliquotSequence {
+	result := AliquotSequence{CycleStart: -1}
+	seen := map[int]int{}
+	for n > 0 {
+		if ind, ok := seen[n]; ok {
+			result.CycleStart = ind
+			break
+		}
+		if n > limit {
+			result.Escaped = true
+			break
+		}
+		seen[n] = len(result.Terms)
+		result.Terms = append(result.Terms, n)
+
+		sum, err := TrySigmaK(n, 1)
+		if err != nil {
+			result.Escaped = true
+			break
+		}
+		n = sum - n
+	}
+	return result
+}
+
+// AliquotCycles returns every aliquot cycle (perfect numbers, amicable pairs, and sociable chains)
+// whose members are all no bigger than limit. Each cycle starts with its smallest member, and the
+// cycles are ordered by that member. The divisor sums are tabulated up front with SigmaTable.
+func AliquotCycles(limit int) [][]int {
+	next := SigmaTable(limit, 1)
+	for i := range next {
+		next[i] -= i
+	}
+
+	const (
+		unvisited = iota
+		onPath
+		finished
+	)
+	state := make([]uint8, limit+1)
+	var cycles [][]int
+	for start := 1; start <= limit; start++ {
+		var path []int
+		n := start
+		for n >= 1 && n <= limit && state[n] == unvisited {
+			state[n] = onPath
+			path = append(path, n)
+			n = next[n]
+		}
+
+		// If we ran back into the current path everything from that point on is a new cycle.
+		if n >= 1 && n <= limit && state[n] == onPath {
+			ind := 0
+			for path[ind] != n {
+				ind++
+			}
+			cycle := append([]int(nil), path[ind:]...)
+			smallest := 0
+			for i := range cycle {
+				if cycle[i] < cycle[smallest] {
+					smallest = i
+				}
+			}
+			cycles = append(cycles, append(cycle[smallest:], cycle[:smallest]...))
+		}
+		for _, val := range path {
+			state[val] = finished
+		}
+	}
+
+	// Cycles are discovered from their first member we reach, so sort them by smallest member.
+	slices.SortFunc(cycles, func(a, b []int) int { return a[0] - b[0] })
+	return cycles
+}
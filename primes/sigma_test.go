@@ -0,0 +1,99 @@
+package primes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSigmaK(t *testing.T) {
+	type s struct {
+		n, k, r int
+	}
+	expected := []s{
+		{1, 1, 1},
+		{12, 0, 6},
+		{12, 1, 28},
+		{12, 2, 210},
+		{1 << 40, 1, 1<<41 - 1},
+		{600851475143, 1, 72 * 840 * 1472 * 6858},
+	}
+
+	for _, e := range expected {
+		if r := SigmaK(e.n, e.k); r != e.r {
+			t.Errorf("SigmaK(%d, %d) returned %d, expected %d", e.n, e.k, r, e.r)
+		}
+	}
+	if r := SigmaK(1<<62, 1); r != 1<<63-1 {
+		t.Errorf("SigmaK(2^62, 1) returned %d, expected 2^63-1", r)
+	}
+	if _, err := TrySigmaK(3<<61, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TrySigmaK(3*2^61, 1) returned error %v, expected ErrOverflow", err)
+	}
+	if _, err := TrySigmaK(1e6, 4); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TrySigmaK(10^6, 4) returned error %v, expected ErrOverflow", err)
+	}
+
+	for _, k := range []int{0, 1, 2} {
+		table := SigmaTable(2000, k)
+		if r := SigmaRange(1000, 2000, k); !reflect.DeepEqual(r, table[1000:]) {
+			t.Errorf("SigmaRange(1000, 2000, %d) didn't match SigmaTable", k)
+		}
+	}
+
+	r, err := TrySigmaRange(9990, 10000, 4)
+	for i, sum := range r {
+		if e := SigmaK(9990+i, 4); sum != e {
+			t.Errorf("TrySigmaRange(9990, 10000, 4)[%d] returned %d, expected %d", i, sum, e)
+		}
+	}
+	if err != nil || len(r) != 11 {
+		t.Errorf("TrySigmaRange(9990, 10000, 4) returned %d sums, %v, expected 11", len(r), err)
+	}
+	if _, err := TrySigmaRange(9990, 10000, 5); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TrySigmaRange(9990, 10000, 5) returned error %v, expected ErrOverflow", err)
+	}
+	if _, err := TrySigmaRange(1e9+7, 1e9+7, 3); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TrySigmaRange(10^9+7, 10^9+7, 3) returned error %v, expected ErrOverflow", err)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	expected := map[int]Abundance{1: Deficient, 6: Perfect, 12: Abundant, 28: Perfect, 945: Abundant, 8128: Perfect, 8129: Deficient}
+	for n, e := range expected {
+		if r := Classify(n); r != e {
+			t.Errorf("Classify(%d) returned %s, expected %s", n, r, e)
+		}
+	}
+}
+
+func TestAliquot(t *testing.T) {
+	if r := Aliquot(12, 1000); !reflect.DeepEqual(r, AliquotSequence{Terms: []int{12, 16, 15, 9, 4, 3, 1}, CycleStart: -1}) {
+		t.Errorf("Aliquot(12, 1000) returned %+v", r)
+	}
+	if r := Aliquot(95, 1000); !reflect.DeepEqual(r, AliquotSequence{Terms: []int{95, 25, 6}, CycleStart: 2}) {
+		t.Errorf("Aliquot(95, 1000) returned %+v", r)
+	}
+	if r := Aliquot(12496, 1e5); r.CycleStart != 0 || len(r.Terms) != 5 {
+		t.Errorf("Aliquot(12496, 10^5) returned %+v, expected a sociable chain of length 5", r)
+	}
+	if r := Aliquot(138, 1000); !r.Escaped {
+		t.Errorf("Aliquot(138, 1000) returned %+v, expected it to escape", r)
+	}
+
+	expected := [][]int{{6}, {28}, {220, 284}, {496}, {1184, 1210}, {2620, 2924}, {5020, 5564}, {6232, 6368}, {8128}}
+	if r := AliquotCycles(1e4); !reflect.DeepEqual(r, expected) {
+		t.Errorf("AliquotCycles(10^4) returned %d, expected %d", r, expected)
+	}
+	if !testing.Short() {
+		var longest []int
+		for _, cycle := range AliquotCycles(1e6) {
+			if len(cycle) > len(longest) {
+				longest = cycle
+			}
+		}
+		if len(longest) != 28 || longest[0] != 14316 {
+			t.Errorf("expected longest chain below 10^6 to start at 14316 and have 28 members; got %d", longest)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package primes
+
+import (
+	"container/heap"
+	"iter"
+	"math/bits"
+	"sort"
+)
+
+// Smooth returns an iterator over every B-smooth number no bigger than n in ascending order,
+// starting with 1. A number is B-smooth if none of its prime factors are bigger than b, so for
+// example Smooth(5, n) produces the Hamming numbers. The numbers are merged with a heap: each
+// number whose largest prime factor is p_i is the parent of its multiples by p_j for every j >= i,
+// which generates every smooth number exactly once and always after its parent.
+func Smooth(b, n int) iter.Seq[int] {
+	primes := Between(2, min(b, n))
+	return func(yield func(int) bool) {
+		if n < 1 {
+			return
+		}
+		pending := productHeap{{value: 1}}
+		for len(pending) > 0 {
+			parent := heap.Pop(&pending).(productNode)
+			if !yield(parent.value) {
+				return
+			}
+			for j := parent.last; j < len(primes); j++ {
+				if parent.value > n/primes[j] {
+					break
+				}
+				heap.Push(&pending, productNode{value: parent.value * primes[j], last: j})
+			}
+		}
+	}
+}
+
+// smoothTableSize bounds the number of entries in the table CountSmooth uses for small values.
+const smoothTableSize = 1 << 22
+
+// CountSmooth returns how many B-smooth numbers there are no bigger than n, including 1. It
+// recurses over the primes up to b from largest to smallest, splitting off every power of the
+// current prime, and uses a table of counts once the remaining bound is small. Only counting the
+// powers of 2 at the bottom of the recursion is left, which is just the bit length.
+func CountSmooth(b, n int) int {
+	if n < 1 {
+		return 0
+	}
+	primes := Between(2, min(b, n))
+	if len(primes) == 0 {
+		return 1
+	}
+
+	// The table holds counts for every number below size using every prime below size, so shrink
+	// it until it fits. A row for a prime that is at least as big as the bound isn't needed,
+	// since every number up to the bound is smooth at that point.
+	size := min(n+1, 1<<16)
+	rows := sort.SearchInts(primes, size)
+	for rows*size > smoothTableSize {
+		size /= 2
+		rows = sort.SearchInts(primes, size)
+	}
+	largest := make([]int, size)
+	for _, prime := range Between(2, size-1) {
+		for m := prime; m < size; m += prime {
+			largest[m] = prime
+		}
+	}
+	table := make([][]int32, rows)
+	for i := range table {
+		table[i] = make([]int32, size)
+		cnt := int32(0)
+		for m := 1; m < size; m++ {
+			if largest[m] <= primes[i] {
+				cnt++
+			}
+			table[i][m] = cnt
+		}
+	}
+
+	// count returns how many numbers no bigger than n have no prime factors beyond primes[i].
+	var count func(n, i int) int
+	count = func(n, i int) int {
+		if n <= primes[i] {
+			return n
+		}
+		if i == 0 {
+			return bits.Len(uint(n))
+		}
+		if n < size {
+			return int(table[i][n])
+		}
+		result := 0
+		for ; n > 0; n /= primes[i] {
+			result += count(n, i-1)
+		}
+		return result
+	}
+	return count(n, len(primes)-1)
+}
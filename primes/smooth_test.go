@@ -0,0 +1,65 @@
+package primes
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSmooth(t *testing.T) {
+	hamming := []int{1, 2, 3, 4, 5, 6, 8, 9, 10, 12, 15, 16, 18, 20, 24, 25, 27, 30}
+	if r := slices.Collect(Smooth(5, 30)); !reflect.DeepEqual(r, hamming) {
+		t.Errorf("Smooth(5, 30) returned %d, expected %d", r, hamming)
+	}
+	if r := slices.Collect(Smooth(1, 100)); !reflect.DeepEqual(r, []int{1}) {
+		t.Errorf("Smooth(1, 100) returned %d, expected [1]", r)
+	}
+	if r := slices.Collect(Smooth(7, 0)); len(r) != 0 {
+		t.Errorf("Smooth(7, 0) returned %d, expected nothing", r)
+	}
+
+	for _, b := range []int{2, 3, 7, 13, 50, 1000} {
+		var expected []int
+		for num := 1; num <= 10000; num++ {
+			if factors := Factor(num); factors[len(factors)-1] <= b {
+				expected = append(expected, num)
+			}
+		}
+		if r := slices.Collect(Smooth(b, 10000)); !reflect.DeepEqual(r, expected) {
+			t.Errorf("Smooth(%d, 10000) returned %d numbers, expected %d", b, len(r), len(expected))
+		}
+	}
+}
+
+func TestCountSmooth(t *testing.T) {
+	type s struct {
+		b, n, r int
+	}
+	expected := []s{
+		{5, 0, 0},
+		{1, 100, 1},
+		{2, 1 << 40, 41},
+		{5, 30, 18},
+		{100, 100, 100},
+		{5, 1000000000, 1530},
+		{100, 1000000000, 2944730},
+	}
+
+	for _, e := range expected {
+		if r := CountSmooth(e.b, e.n); r != e.r {
+			t.Errorf("CountSmooth(%d, %d) returned %d, expected %d", e.b, e.n, r, e.r)
+		}
+	}
+
+	for _, b := range []int{3, 7, 23, 97, 5000, 70000} {
+		for _, n := range []int{1, 97, 5000, 65535, 65536, 1000000} {
+			cnt := 0
+			for range Smooth(b, n) {
+				cnt++
+			}
+			if r := CountSmooth(b, n); r != cnt {
+				t.Errorf("CountSmooth(%d, %d) returned %d, expected %d", b, n, r, cnt)
+			}
+		}
+	}
+}
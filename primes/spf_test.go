@@ -0,0 +1,53 @@
+package primes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSPF(t *testing.T) {
+	const limit = 5000
+	for _, table := range []*SPF{NewSPF(limit), NewCompactSPF(limit)} {
+		if r := table.Primes(); !reflect.DeepEqual(r, Between(1, limit)) {
+			t.Errorf("SPF table found %d primes up to %d, expected %d", len(r), limit, len(Between(1, limit)))
+		}
+		for num := 1; num <= limit; num++ {
+			if r, e := table.Factor(num), Factor(num); !reflect.DeepEqual(r, e) {
+				t.Errorf("SPF Factor(%d) returned %d, expected %d", num, r, e)
+			}
+			if r, e := table.Divisors(num), Divisors(num); !reflect.DeepEqual(r, e) {
+				t.Errorf("SPF Divisors(%d) returned %d, expected %d", num, r, e)
+			}
+			if r, e := table.CountDivisors(num), CountDivisors(num); r != e {
+				t.Errorf("SPF CountDivisors(%d) returned %d, expected %d", num, r, e)
+			}
+			if r, e := table.EulerPhi(num), EulerPhi(num); r != e {
+				t.Errorf("SPF EulerPhi(%d) returned %d, expected %d", num, r, e)
+			}
+		}
+	}
+}
+
+func TestSPFNotNatural(t *testing.T) {
+	table := NewSPF(10)
+	calls := map[string]func(int){
+		"Factor":        func(num int) { table.Factor(num) },
+		"FactorMap":     func(num int) { table.FactorMap(num) },
+		"CountDivisors": func(num int) { table.CountDivisors(num) },
+		"Divisors":      func(num int) { table.Divisors(num) },
+		"EulerPhi":      func(num int) { table.EulerPhi(num) },
+	}
+	for name, call := range calls {
+		for _, num := range []int{0, -6} {
+			func() {
+				defer func() {
+					if err, _ := recover().(error); !errors.Is(err, ErrNotNatural) {
+						t.Errorf("SPF.%s(%d) panicked with %v, expected an error wrapping ErrNotNatural", name, num, err)
+					}
+				}()
+				call(num)
+			}()
+		}
+	}
+}
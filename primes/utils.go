@@ -1,10 +1,30 @@
 package primes
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Integer is the set of all integer types. Functions that have a generic version for any integer
+// type use the suffix Of, with the plain name kept as the int instantiation.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Number is the set of all integer and floating point types.
+type Number interface {
+	Integer | ~float32 | ~float64
+}
 
 // Pow is an integer version of the math.Pow function. It utilizes exponentiation by squaring.
-func Pow(a, b int) int {
-	p := int(1)
+func Pow(a, b int) int { return PowOf(a, b) }
+
+// PowOf is the generic version of Pow. Like normal arithmetic on the type, the result wraps
+// around if it overflows.
+func PowOf[T Integer](a, b T) T {
+	p := T(1)
 	for b > 0 {
 		if b&1 != 0 {
 			p *= a
@@ -15,25 +35,73 @@ func Pow(a, b int) int {
 	return p
 }
 
-// PowMod is similar to Pow, but does modular exponentation. It returns (a^b)%m
-func PowMod(a, b, m int) int {
-	p := int(1)
-	for b > 0 {
-		if b&1 != 0 {
-			p = (p * a) % m
+// checkedMul returns a*b for non-negative a and b, and whether the result fit in an int.
+func checkedMul(a, b int) (int, bool) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int(lo), hi == 0 && lo <= math.MaxInt
+}
+
+// checkedPow returns a^b for non-negative a and b, and whether the result fit in an int.
+func checkedPow(a, b int) (int, bool) {
+	result := 1
+	for i := 0; i < b; i++ {
+		var ok bool
+		if result, ok = checkedMul(result, a); !ok {
+			return 0, false
 		}
+	}
+	return result, true
+}
 
-		b >>= 1
-		a = (a * a) % m
+// reduceMod returns a%m in the range [0, m) as a uint64, panicking if the modulus isn't positive.
+func reduceMod[T Integer](a, m T) uint64 {
+	if m <= 0 {
+		panic(fmt.Errorf("cannot use non-positive modulus %d", m))
 	}
-	return p
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return uint64(r)
+}
+
+// MulMod returns (a*b)%m without overflowing, no matter how big the modulus is.
+func MulMod(a, b, m int) int { return MulModOf(a, b, m) }
+
+// MulModOf is the generic version of MulMod. The product is calculated with 128 bits of precision
+// using math/bits, and negative numbers are treated as their positive equivalent, so the result is
+// always in the range [0, m).
+func MulModOf[T Integer](a, b, m T) T {
+	return T(mulMod64(reduceMod(a, m), reduceMod(b, m), uint64(m)))
+}
+
+// PowMod is similar to Pow, but does modular exponentation. It returns (a^b)%m, and unlike a naive
+// implementation it never overflows. For many exponentiations under the same odd modulus using a
+// Montgomery context directly can be faster.
+func PowMod(a, b, m int) int { return PowModOf(a, b, m) }
+
+// PowModOf is the generic version of PowMod. All of the intermediate products are done with 128
+// bits of precision, so it works for any modulus the type can hold. Negative bases are treated as
+// their positive equivalent, so the result is always in the range [0, m).
+func PowModOf[T Integer](a, b, m T) T {
+	if b < 0 {
+		b = 0
+	}
+	return T(powMod64(reduceMod(a, m), uint64(b), uint64(m)))
 }
 
 // IsSquare tests to see if an integer value is the square of another integer.
-func IsSquare(x int) bool {
+func IsSquare(x int) bool { return IsSquareOf(x) }
+
+// IsSquareOf is the generic version of IsSquare. The root is calculated exactly with integer
+// math, so it works even for numbers too big to be represented exactly as a float64.
+func IsSquareOf[T Integer](x T) bool {
+	if x < 0 {
+		return false
+	}
 	if h := x & 0xf; h != 0 && h != 1 && h != 4 && h != 9 {
 		return false
 	}
-	sqr := int(math.Sqrt(float64(x)))
-	return sqr*sqr == x
+	sqr := isqrt64(uint64(x))
+	return sqr*sqr == uint64(x)
 }
@@ -0,0 +1,39 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPowModOf(t *testing.T) {
+	if r := PowMod(3, 200, 1e9+7); r != 136318165 {
+		t.Errorf("PowMod(3, 200, 1e9+7) returned %d, expected 136318165", r)
+	}
+	if r := PowMod(-2, 3, 7); r != 6 {
+		t.Errorf("PowMod(-2, 3, 7) returned %d, expected 6", r)
+	}
+	if r := PowModOf[uint64](1<<63+5, 2, 1<<64-59); r != 13835058055282164858 {
+		t.Errorf("PowModOf(2^63+5, 2, 2^64-59) returned %d, expected 13835058055282164858", r)
+	}
+	if r := PowModOf[int8](100, 100, 127); r != 25 {
+		t.Errorf("PowModOf[int8](100, 100, 127) returned %d, expected 25", r)
+	}
+	if r := PowModOf[uint32](math.MaxUint32-1, 3, math.MaxUint32); r != math.MaxUint32-1 {
+		t.Errorf("PowModOf[uint32](2^32-2, 3, 2^32-1) returned %d, expected %d", r, uint32(math.MaxUint32-1))
+	}
+}
+
+func TestIsSquareOf(t *testing.T) {
+	if !IsSquareOf[uint64](math.MaxUint32 * math.MaxUint32) {
+		t.Errorf("expected (2^32-1)^2 to be a square")
+	}
+	if IsSquareOf[uint64](math.MaxUint32*math.MaxUint32 + 1) {
+		t.Errorf("expected (2^32-1)^2+1 not to be a square")
+	}
+	if !IsSquare(999999999*999999999) || IsSquare(999999999*999999999-1) {
+		t.Errorf("IsSquare gave the wrong answer around 999999999^2")
+	}
+	if IsSquareOf[int8](-4) || !IsSquareOf[uint8](225) {
+		t.Errorf("IsSquareOf gave the wrong answer for small types")
+	}
+}